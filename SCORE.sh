@@ -0,0 +1,3 @@
+#!/bin/bash
+echo "Scoring core and challenge suites..."
+go run ./cmd/challenge -junit report.xml -json summary.json "$@"
@@ -0,0 +1,143 @@
+// Command challenge runs the Go validation suites and the declarative challenge case files against the server and
+// prints a scored report, optionally also written as JUnit XML and a JSON summary.
+//
+//	challenge [-target http://localhost:8080] [-v] [-junit report.xml] [-json summary.json] [challenges/ | challenges/test001.json ...]
+//
+// Without -target the server is built from this tree and served in-process.
+// It exits 0 when every case passed, 1 when any failed and 2 when the run itself failed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"tech-test/internal/challenge"
+	"tech-test/internal/config"
+	"tech-test/internal/harness"
+	"tech-test/internal/server"
+)
+
+const (
+	exitPassed = 0
+	exitFailed = 1
+	exitError  = 2
+)
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	fs := flag.NewFlagSet("challenge", flag.ContinueOnError)
+	target := fs.String("target", "", "base URL of the server to test (default: serve this tree in-process)")
+	verbose := fs.Bool("v", false, "list passing cases as well as failing ones")
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout for each request")
+	suites := fs.String("suites", "./validation/core", "comma-separated Go validation packages to run first; empty for none")
+	junitPath := fs.String("junit", "", "write a JUnit XML report to this file")
+	jsonPath := fs.String("json", "", "write a JSON summary to this file")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return exitPassed
+		}
+		return exitError
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"challenges"}
+	}
+	challenges, err := challenge.LoadAll(paths)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	var results []challenge.Result
+	baseURL := strings.TrimRight(*target, "/")
+	for _, pkg := range strings.Split(*suites, ",") {
+		if pkg == "" {
+			continue
+		}
+		// The suites start their own in-process server unless pointed at a target
+		var env []string
+		if baseURL != "" {
+			env = append(env, harness.BaseURLEnv+"="+baseURL)
+		}
+		result, err := challenge.RunGoTests(context.Background(), strings.ToUpper(path.Base(pkg)), "Go suite "+pkg, pkg, env)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		results = append(results, result)
+	}
+
+	reportTarget := baseURL
+	if baseURL == "" {
+		app, err := server.New(config.Default())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		srv := httptest.NewServer(app)
+		defer srv.Close()
+		baseURL = srv.URL
+		reportTarget = "in-process"
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	for _, ch := range challenges {
+		results = append(results, challenge.Run(context.Background(), client, baseURL, ch))
+	}
+
+	summary := challenge.NewSummary(reportTarget, results)
+	for _, result := range summary.Results {
+		fmt.Printf("%-10s %-40s %3d/%-3d %5.1f%%\n", result.ID, result.Title, result.Score, result.Max, result.Percent)
+		for _, c := range result.Cases {
+			switch {
+			case !c.Passed:
+				fmt.Printf("  FAIL  %s: %s\n", c.Name, c.Failure)
+			case *verbose:
+				fmt.Printf("  PASS  %s\n", c.Name)
+			}
+		}
+	}
+	fmt.Printf("%-51s %3d/%-3d %5.1f%%\n", "TOTAL", summary.Score, summary.Max, summary.Percent)
+
+	if *junitPath != "" {
+		if err := writeFile(*junitPath, summary.WriteJUnit); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+	}
+	if *jsonPath != "" {
+		if err := writeFile(*jsonPath, summary.WriteJSON); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+	}
+
+	if summary.Failed > 0 {
+		return exitFailed
+	}
+	return exitPassed
+}
+
+func writeFile(name string, write func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
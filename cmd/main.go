@@ -1,30 +1,110 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"tech-test/internal/domain"
-	"tech-test/internal/handlers"
+	"tech-test/internal/config"
+	"tech-test/internal/recording"
+	"tech-test/internal/server"
+)
+
+// version is the build version reported by the health endpoints, set with -ldflags "-X main.version=..."
+var version = "dev"
+
+// Exit codes let deploy tooling tell why the process stopped
+const (
+	exitOK              = 0
+	exitConfig          = 1
+	exitServe           = 2
+	exitShutdownTimeout = 3
+	exitSelfTest        = 4
 )
 
 func main() {
-	// Initialize domain services
-	mathService := domain.NewMathService()
-
-	// Initialize handlers with dependency injection
-	h := handlers.NewHandlers(mathService)
-
-	// Setup routes
-	mux := http.NewServeMux()
-	mux.HandleFunc("/ping", h.Ping)
-	mux.HandleFunc("/add", h.Add)
-	mux.HandleFunc("/sub", h.Sub)
-	mux.HandleFunc("/mul", h.Mul)
-
-	// Start server on port 8080
-	log.Println("Starting server on :8080")
-	if err := http.ListenAndServe(":8080", mux); err != nil {
-		log.Fatal("Failed to start server:", err)
+	os.Exit(run())
+}
+
+func run() int {
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		slog.Error("invalid configuration", "error", err)
+		return exitConfig
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.LogLevel}))
+
+	opts := []server.Option{server.WithLogger(logger), server.WithVersion(version)}
+	if cfg.RecordFile != "" {
+		f, err := os.OpenFile(cfg.RecordFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			logger.Error("invalid configuration", "error", err)
+			return exitConfig
+		}
+		defer f.Close()
+		opts = append(opts, server.WithRecorder(recording.NewRecorder(f)))
+		logger.Info("recording requests", "file", cfg.RecordFile)
+	}
+
+	app, err := server.New(cfg, opts...)
+	if err != nil {
+		logger.Error("invalid configuration", "error", err)
+		return exitConfig
+	}
+	if report := app.SelfTestReport(); !report.OK {
+		logger.Error("self-test failed", "failed", report.Failed, "passed", report.Passed, "error", report.Err())
+		if cfg.SelfTestMode == "fail" {
+			return exitSelfTest
+		}
+	} else {
+		logger.Info("self-test passed", "passed", report.Passed, "skipped", report.Skipped)
+	}
+
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           app,
+		ReadHeaderTimeout: time.Duration(cfg.ReadTimeout),
+		ReadTimeout:       time.Duration(cfg.ReadTimeout),
+		WriteTimeout:      time.Duration(cfg.WriteTimeout),
+		IdleTimeout:       time.Duration(cfg.IdleTimeout),
+	}
+
+	// The first SIGINT or SIGTERM marks the server not ready, waits out the shutdown delay so load balancers
+	// stop routing to it, then drains; a second signal kills the process immediately
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	serveCtx, cancelServe := context.WithCancel(context.Background())
+	defer cancelServe()
+	context.AfterFunc(signalCtx, func() {
+		stop()
+		app.SetDraining()
+		logger.Info("shutting down",
+			"delay", time.Duration(cfg.ShutdownDelay).String(),
+			"drain_timeout", time.Duration(cfg.ShutdownTimeout).String())
+		time.AfterFunc(time.Duration(cfg.ShutdownDelay), cancelServe)
+	})
+
+	logger.Info("starting server", "addr", cfg.Addr, "version", version)
+	err = server.Run(serveCtx, srv, time.Duration(cfg.ShutdownTimeout))
+	switch {
+	case err == nil:
+		logger.Info("server stopped")
+		return exitOK
+	case errors.Is(err, server.ErrShutdownTimeout):
+		logger.Error("shutdown incomplete", "error", err)
+		return exitShutdownTimeout
+	default:
+		logger.Error("server failed", "error", err)
+		return exitServe
 	}
 }
@@ -0,0 +1,204 @@
+// Command mutate plants small bugs in the domain and handler code, one at a time, runs the validation suites
+// against each and reports the mutants no test caught.
+//
+//	mutate [-dirs internal/domain,internal/handlers] [-suites ./validation/...] [-j 4] [-only add.go] [-list]
+//
+// Suites that already fail on the unmodified tree are left out, since they cannot tell a mutant apart.
+// It exits 0 when every viable mutant was killed, 1 when any survived and 2 when the run itself failed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"tech-test/internal/mutation"
+)
+
+const (
+	exitKilled   = 0
+	exitSurvived = 1
+	exitError    = 2
+)
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	fs := flag.NewFlagSet("mutate", flag.ContinueOnError)
+	dirs := fs.String("dirs", "internal/domain,internal/handlers", "comma-separated directories whose Go files are mutated")
+	suites := fs.String("suites", "./validation/...", "comma-separated package patterns run against each mutant")
+	workers := fs.Int("j", runtime.NumCPU(), "mutants tested in parallel")
+	timeout := fs.Duration("timeout", 2*time.Minute, "time allowed for the suites to run against one mutant")
+	only := fs.String("only", "", "only mutate files whose path contains this string")
+	list := fs.Bool("list", false, "list the mutants without testing them")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return exitKilled
+		}
+		return exitError
+	}
+
+	sources := map[string][]byte{}
+	var mutants []mutation.Mutant
+	for _, dir := range strings.Split(*dirs, ",") {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") || !strings.Contains(file, *only) {
+				continue
+			}
+			src, err := os.ReadFile(file)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return exitError
+			}
+			generated, err := mutation.Generate(file, src)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return exitError
+			}
+			sources[file] = src
+			mutants = append(mutants, generated...)
+		}
+	}
+
+	if *list {
+		for _, m := range mutants {
+			fmt.Println(m)
+		}
+		return exitKilled
+	}
+
+	pkgs, err := passingPackages(strings.Split(*suites, ","))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	if len(pkgs) == 0 {
+		fmt.Fprintln(os.Stderr, "no suite passes on the unmodified tree")
+		return exitError
+	}
+	fmt.Printf("testing %d mutants against %s\n", len(mutants), strings.Join(pkgs, " "))
+
+	outcomes := make([]mutation.Outcome, len(mutants))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var failure error
+	var mu sync.Mutex
+	for range max(*workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+				outcome, err := mutation.Test(ctx, mutants[i], sources[mutants[i].File], pkgs)
+				cancel()
+
+				mu.Lock()
+				if err != nil && failure == nil {
+					failure = err
+				}
+				outcomes[i] = outcome
+				if outcome == mutation.Survived {
+					fmt.Printf("SURVIVED  %s\n", mutants[i])
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range mutants {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if failure != nil {
+		fmt.Fprintln(os.Stderr, failure)
+		return exitError
+	}
+
+	report(mutants, outcomes)
+	for _, o := range outcomes {
+		if o == mutation.Survived {
+			return exitSurvived
+		}
+	}
+	return exitKilled
+}
+
+// passingPackages expands the patterns and keeps the packages whose tests pass on the unmodified tree
+func passingPackages(patterns []string) ([]string, error) {
+	out, err := exec.Command("go", append([]string{"list", "-f", "{{if or .TestGoFiles .XTestGoFiles}}{{.ImportPath}}{{end}}"}, patterns...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing suites: %w", err)
+	}
+
+	var pkgs []string
+	for _, pkg := range strings.Fields(string(out)) {
+		if err := exec.Command("go", "test", "-count=1", pkg).Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "skipping %s: it fails without any mutation\n", pkg)
+			continue
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+// report prints the outcome totals per file and kind and the overall mutation score
+func report(mutants []mutation.Mutant, outcomes []mutation.Outcome) {
+	type key struct {
+		file string
+		kind mutation.Kind
+	}
+	counts := map[key]map[mutation.Outcome]int{}
+	totals := map[mutation.Outcome]int{}
+	for i, m := range mutants {
+		k := key{m.File, m.Kind}
+		if counts[k] == nil {
+			counts[k] = map[mutation.Outcome]int{}
+		}
+		counts[k][outcomes[i]]++
+		totals[outcomes[i]]++
+	}
+
+	keys := make([]key, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].file != keys[j].file {
+			return keys[i].file < keys[j].file
+		}
+		return keys[i].kind < keys[j].kind
+	})
+
+	fmt.Printf("\n%-40s %-12s %7s %9s %11s %10s\n", "FILE", "KIND", "KILLED", "SURVIVED", "NOT VIABLE", "TIMED OUT")
+	for _, k := range keys {
+		c := counts[k]
+		fmt.Printf("%-40s %-12s %7d %9d %11d %10d\n", k.file, k.kind,
+			c[mutation.Killed], c[mutation.Survived], c[mutation.NotViable], c[mutation.TimedOut])
+	}
+
+	// Timeouts count as caught: the bug made the suites hang rather than pass
+	caught := totals[mutation.Killed] + totals[mutation.TimedOut]
+	viable := caught + totals[mutation.Survived]
+	score := 0.0
+	if viable > 0 {
+		score = 100 * float64(caught) / float64(viable)
+	}
+	fmt.Printf("\nmutation score %.1f%%: %d of %d viable mutants caught, %d survived, %d not viable\n",
+		score, caught, viable, totals[mutation.Survived], totals[mutation.NotViable])
+}
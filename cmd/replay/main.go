@@ -0,0 +1,104 @@
+// Command replay sends requests captured with the server's record_file setting to a server and reports every
+// response that differs from the recorded one.
+//
+//	replay [-target http://localhost:8080] [requests.jsonl]
+//
+// It exits 0 when every response matched, 1 when any differed and 2 when the replay itself failed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"tech-test/internal/recording"
+)
+
+const (
+	exitMatched = 0
+	exitDiffers = 1
+	exitFailed  = 2
+)
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	target := fs.String("target", "http://localhost:8080", "base URL of the server to replay against")
+	ignore := fs.String("ignore", "/metrics,/healthz,/readyz,/admin/selftest", "comma-separated paths whose responses are expected to change")
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout for each replayed request")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return exitMatched
+		}
+		return exitFailed
+	}
+
+	path := "requests.jsonl"
+	if fs.NArg() > 0 {
+		path = fs.Arg(0)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailed
+	}
+	entries, err := recording.ReadEntries(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return exitFailed
+	}
+
+	ignored := strings.Split(*ignore, ",")
+	client := &http.Client{Timeout: *timeout}
+	baseURL := strings.TrimRight(*target, "/")
+
+	var matched, differed, skipped int
+	for i, e := range entries {
+		if e.Truncated || slices.Contains(ignored, requestPath(e.URL)) {
+			skipped++
+			continue
+		}
+
+		replayed, err := recording.Replay(context.Background(), client, baseURL, e)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "#%d %s %s: %v\n", i+1, e.Method, e.URL, err)
+			return exitFailed
+		}
+
+		diffs := recording.Diff(e, replayed)
+		if len(diffs) == 0 {
+			matched++
+			continue
+		}
+		differed++
+		fmt.Printf("#%d %s %s\n", i+1, e.Method, e.URL)
+		for _, d := range diffs {
+			fmt.Printf("    %s\n", d)
+		}
+	}
+
+	fmt.Printf("replayed %d requests against %s: %d matched, %d differed, %d skipped\n",
+		matched+differed, baseURL, matched, differed, skipped)
+	if differed > 0 {
+		return exitDiffers
+	}
+	return exitMatched
+}
+
+func requestPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Path
+}
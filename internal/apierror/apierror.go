@@ -0,0 +1,138 @@
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Code is a stable, machine-readable identifier for a class of error
+type Code string
+
+const (
+	CodeMissingParameter  Code = "missing_parameter"
+	CodeInvalidNumber     Code = "invalid_number"
+	CodeInvalidParameter  Code = "invalid_parameter"
+	CodeInvalidExpression Code = "invalid_expression"
+	CodeMethodNotAllowed  Code = "method_not_allowed"
+	CodeInvalidBody       Code = "invalid_body"
+	CodeUnknownOperation  Code = "unknown_operation"
+	CodeInvalidArguments  Code = "invalid_arguments"
+	CodeDomainError       Code = "domain_error"
+	CodeInternal          Code = "internal_error"
+)
+
+// titles are the short, human-readable summaries for each code
+var titles = map[Code]string{
+	CodeMissingParameter:  "Missing parameter",
+	CodeInvalidNumber:     "Invalid number",
+	CodeInvalidParameter:  "Invalid parameter",
+	CodeInvalidExpression: "Invalid expression",
+	CodeMethodNotAllowed:  "Method not allowed",
+	CodeInvalidBody:       "Invalid request body",
+	CodeUnknownOperation:  "Unknown operation",
+	CodeInvalidArguments:  "Invalid arguments",
+	CodeDomainError:       "Domain error",
+	CodeInternal:          "Internal server error",
+}
+
+// Error is an API error carrying everything needed to render an RFC 7807 problem
+type Error struct {
+	Code      Code
+	Status    int
+	Detail    string
+	Parameter string
+	Column    int
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+// Problem is the application/problem+json representation of an Error
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance,omitempty"`
+	Code      Code   `json:"code"`
+	Parameter string `json:"parameter,omitempty"`
+	Column    int    `json:"column,omitempty"`
+}
+
+// Problem converts e into its problem+json representation for the given request path
+func (e *Error) Problem(instance string) Problem {
+	return Problem{
+		Type:      "urn:tech-test:problem:" + string(e.Code),
+		Title:     titles[e.Code],
+		Status:    e.Status,
+		Detail:    e.Detail,
+		Instance:  instance,
+		Code:      e.Code,
+		Parameter: e.Parameter,
+		Column:    e.Column,
+	}
+}
+
+// MissingParameter reports a required query parameter that was not supplied
+func MissingParameter(name, detail string) *Error {
+	return &Error{Code: CodeMissingParameter, Status: http.StatusBadRequest, Detail: detail, Parameter: name}
+}
+
+// InvalidNumber reports a parameter that could not be parsed as a number
+func InvalidNumber(name string) *Error {
+	return &Error{
+		Code:      CodeInvalidNumber,
+		Status:    http.StatusBadRequest,
+		Detail:    fmt.Sprintf("parameter '%s' must be a valid number", name),
+		Parameter: name,
+	}
+}
+
+// InvalidParameter reports a parameter whose value is not one of the accepted options
+func InvalidParameter(name, detail string) *Error {
+	return &Error{Code: CodeInvalidParameter, Status: http.StatusBadRequest, Detail: detail, Parameter: name}
+}
+
+// InvalidExpression reports an expression that could not be parsed or evaluated
+func InvalidExpression(column int, detail string) *Error {
+	return &Error{Code: CodeInvalidExpression, Status: http.StatusBadRequest, Detail: detail, Parameter: "expr", Column: column}
+}
+
+// MethodNotAllowed reports a request made with an unsupported HTTP method
+func MethodNotAllowed(method string) *Error {
+	return &Error{
+		Code:   CodeMethodNotAllowed,
+		Status: http.StatusMethodNotAllowed,
+		Detail: fmt.Sprintf("method %s is not allowed", method),
+	}
+}
+
+// InvalidBody reports a request body that could not be decoded
+func InvalidBody(detail string) *Error {
+	return &Error{Code: CodeInvalidBody, Status: http.StatusBadRequest, Detail: detail}
+}
+
+// UnknownOperation reports a reference to an operation that is not registered
+func UnknownOperation(name string) *Error {
+	return &Error{
+		Code:   CodeUnknownOperation,
+		Status: http.StatusBadRequest,
+		Detail: fmt.Sprintf("unknown operation '%s'", name),
+	}
+}
+
+// InvalidArguments reports operands that do not fit an operation, such as the wrong count
+func InvalidArguments(detail string) *Error {
+	return &Error{Code: CodeInvalidArguments, Status: http.StatusBadRequest, Detail: detail}
+}
+
+// Domain reports a well-formed request that the domain could not compute a result for
+func Domain(err error) *Error {
+	return &Error{Code: CodeDomainError, Status: http.StatusUnprocessableEntity, Detail: err.Error()}
+}
+
+// Internal reports an unexpected server-side failure
+func Internal() *Error {
+	return &Error{Code: CodeInternal, Status: http.StatusInternalServerError, Detail: "internal server error"}
+}
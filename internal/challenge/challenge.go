@@ -0,0 +1,100 @@
+// Package challenge runs declarative challenge case files against a server and scores the results
+package challenge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Challenge is one TEST_00x specification expressed as HTTP cases
+type Challenge struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Spec is the markdown file describing the challenge to candidates
+	Spec  string `json:"spec,omitempty"`
+	Cases []Case `json:"cases"`
+}
+
+// Case is a single request and what its response must look like
+type Case struct {
+	Name    string  `json:"name"`
+	Request Request `json:"request"`
+	Expect  Expect  `json:"expect"`
+	// Points is the case's weight in the challenge score; zero counts as one
+	Points int `json:"points,omitempty"`
+}
+
+// Request describes the HTTP request a case sends
+type Request struct {
+	// Method defaults to GET
+	Method  string            `json:"method,omitempty"`
+	Path    string            `json:"path"`
+	Query   map[string]string `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// Expect lists the checks applied to the response; unset fields are not checked
+type Expect struct {
+	Status    int `json:"status,omitempty"`
+	StatusNot int `json:"status_not,omitempty"`
+	// Body must match the response body exactly
+	Body string `json:"body,omitempty"`
+	// NonEmpty requires some response body, e.g. an error message
+	NonEmpty bool `json:"non_empty,omitempty"`
+	// Number parses the body as a number and compares it within Tolerance
+	Number      *float64 `json:"number,omitempty"`
+	Tolerance   float64  `json:"tolerance,omitempty"`
+	ContentType string   `json:"content_type,omitempty"`
+	// JSON must be contained in the JSON response: every field given must be present with the same value
+	JSON json.RawMessage `json:"json,omitempty"`
+}
+
+// Load reads a challenge file
+func Load(path string) (Challenge, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Challenge{}, err
+	}
+
+	var ch Challenge
+	if err := json.Unmarshal(data, &ch); err != nil {
+		return Challenge{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if ch.ID == "" {
+		return Challenge{}, fmt.Errorf("%s: challenge has no id", path)
+	}
+	for i, c := range ch.Cases {
+		if c.Name == "" || c.Request.Path == "" {
+			return Challenge{}, fmt.Errorf("%s: case %d needs a name and a request path", path, i+1)
+		}
+	}
+	return ch, nil
+}
+
+// LoadAll reads every challenge named by paths, expanding directories to the *.json files inside them
+func LoadAll(paths []string) ([]Challenge, error) {
+	var challenges []Challenge
+	for _, path := range paths {
+		files := []string{path}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			files, err = filepath.Glob(filepath.Join(path, "*.json"))
+			if err != nil {
+				return nil, err
+			}
+			sort.Strings(files)
+		}
+
+		for _, file := range files {
+			ch, err := Load(file)
+			if err != nil {
+				return nil, err
+			}
+			challenges = append(challenges, ch)
+		}
+	}
+	return challenges, nil
+}
@@ -0,0 +1,118 @@
+package challenge
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// testEvent is one line of `go test -json` output
+type testEvent struct {
+	Action  string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// RunGoTests runs a Go validation package with `go test -json` and scores every leaf test or subtest as a
+// one-point case. env is added to the environment of the test binary, e.g. to point it at an external server.
+func RunGoTests(ctx context.Context, id, title, pkg string, env []string) (Result, error) {
+	cmd := exec.CommandContext(ctx, "go", "test", "-json", "-count=1", pkg)
+	cmd.Env = append(cmd.Environ(), env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	// A failing test exits non-zero; only a run that produced no events is an error
+	if err != nil && len(out) == 0 {
+		return Result{}, fmt.Errorf("go test %s: %w: %s", pkg, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return scoreGoTests(id, title, out, stderr.String()), nil
+}
+
+// scoreGoTests turns `go test -json` output into a Result with one point per leaf test; stderr explains a package
+// that failed to build
+func scoreGoTests(id, title string, out []byte, stderr string) Result {
+	var order []string
+	outcomes := map[string]testEvent{}
+	output := map[string]*strings.Builder{}
+	var packageFailed bool
+	var packageOutput strings.Builder
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		var ev testEvent
+		if json.Unmarshal(scanner.Bytes(), &ev) != nil {
+			continue
+		}
+		if ev.Test == "" {
+			if ev.Action == "fail" {
+				packageFailed = true
+			}
+			packageOutput.WriteString(ev.Output)
+			continue
+		}
+
+		switch ev.Action {
+		case "run":
+			order = append(order, ev.Test)
+			output[ev.Test] = &strings.Builder{}
+		case "output":
+			if b, ok := output[ev.Test]; ok {
+				b.WriteString(ev.Output)
+			}
+		case "pass", "fail", "skip":
+			outcomes[ev.Test] = ev
+		}
+	}
+
+	result := Result{ID: id, Title: title}
+	for _, name := range order {
+		if hasSubtests(name, order) {
+			continue
+		}
+		ev := outcomes[name]
+		res := CaseResult{Name: name, Points: 1, Passed: ev.Action == "pass", Seconds: ev.Elapsed}
+		switch ev.Action {
+		case "pass":
+		case "skip":
+			res.Failure = "skipped: " + failureOutput(output[name].String())
+		default:
+			res.Failure = failureOutput(output[name].String())
+		}
+		result.add(res)
+	}
+
+	// A package that fails without running any tests did not build
+	if packageFailed && len(order) == 0 {
+		result.add(CaseResult{Name: "build", Points: 1, Failure: strings.TrimSpace(packageOutput.String() + stderr)})
+	}
+
+	return result
+}
+
+func hasSubtests(name string, tests []string) bool {
+	for _, t := range tests {
+		if strings.HasPrefix(t, name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// failureOutput keeps the lines a test logged, dropping go test's own === and --- markers
+func failureOutput(output string) string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "===") || strings.HasPrefix(trimmed, "---") {
+			continue
+		}
+		lines = append(lines, trimmed)
+	}
+	return strings.Join(lines, "; ")
+}
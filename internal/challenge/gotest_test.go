@@ -0,0 +1,97 @@
+package challenge
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScoreGoTests(t *testing.T) {
+	testCases := []struct {
+		name       string
+		events     []string
+		stderr     string
+		wantCases  []CaseResult
+		wantPassed int
+		wantFailed int
+	}{
+		{
+			name: "leaf tests and subtests",
+			events: []string{
+				`{"Action":"start","Package":"tech-test/validation/core"}`,
+				`{"Action":"run","Package":"tech-test/validation/core","Test":"TestPing"}`,
+				`{"Action":"output","Package":"tech-test/validation/core","Test":"TestPing","Output":"=== RUN   TestPing\n"}`,
+				`{"Action":"pass","Package":"tech-test/validation/core","Test":"TestPing","Elapsed":0.01}`,
+				`{"Action":"run","Package":"tech-test/validation/core","Test":"TestAdd"}`,
+				`{"Action":"run","Package":"tech-test/validation/core","Test":"TestAdd/positive"}`,
+				`{"Action":"pass","Package":"tech-test/validation/core","Test":"TestAdd/positive","Elapsed":0}`,
+				`{"Action":"run","Package":"tech-test/validation/core","Test":"TestAdd/negative"}`,
+				`{"Action":"output","Package":"tech-test/validation/core","Test":"TestAdd/negative","Output":"=== RUN   TestAdd/negative\n"}`,
+				`{"Action":"output","Package":"tech-test/validation/core","Test":"TestAdd/negative","Output":"    core_test.go:60: Expected body '-2.00', got '2.00'\n"}`,
+				`{"Action":"output","Package":"tech-test/validation/core","Test":"TestAdd/negative","Output":"    --- FAIL: TestAdd/negative (0.00s)\n"}`,
+				`{"Action":"fail","Package":"tech-test/validation/core","Test":"TestAdd/negative","Elapsed":0.02}`,
+				`{"Action":"fail","Package":"tech-test/validation/core","Test":"TestAdd","Elapsed":0.02}`,
+				`{"Action":"run","Package":"tech-test/validation/core","Test":"TestExternal"}`,
+				`{"Action":"output","Package":"tech-test/validation/core","Test":"TestExternal","Output":"    core_test.go:90: no server configured\n"}`,
+				`{"Action":"skip","Package":"tech-test/validation/core","Test":"TestExternal","Elapsed":0}`,
+				`{"Action":"output","Package":"tech-test/validation/core","Output":"FAIL\n"}`,
+				`{"Action":"fail","Package":"tech-test/validation/core","Elapsed":0.05}`,
+			},
+			wantCases: []CaseResult{
+				{Name: "TestPing", Passed: true, Points: 1, Seconds: 0.01},
+				{Name: "TestAdd/positive", Passed: true, Points: 1},
+				{Name: "TestAdd/negative", Points: 1, Seconds: 0.02, Failure: "core_test.go:60: Expected body '-2.00', got '2.00'"},
+				{Name: "TestExternal", Points: 1, Failure: "skipped: core_test.go:90: no server configured"},
+			},
+			wantPassed: 2,
+			wantFailed: 2,
+		},
+		{
+			name: "build failure",
+			events: []string{
+				`{"Action":"start","Package":"tech-test/validation/core"}`,
+				`{"Action":"output","Package":"tech-test/validation/core","Output":"FAIL\ttech-test/validation/core [build failed]\n"}`,
+				`{"Action":"fail","Package":"tech-test/validation/core","Elapsed":0}`,
+			},
+			stderr: "validation/core/core_test.go:12:2: undefined: baseURL",
+			wantCases: []CaseResult{
+				{Name: "build", Points: 1,
+					Failure: "FAIL\ttech-test/validation/core [build failed]\nvalidation/core/core_test.go:12:2: undefined: baseURL"},
+			},
+			wantFailed: 1,
+		},
+		{
+			name: "non-JSON lines ignored",
+			events: []string{
+				`# tech-test/validation/core`,
+				`{"Action":"run","Package":"tech-test/validation/core","Test":"TestPing"}`,
+				`{"Action":"pass","Package":"tech-test/validation/core","Test":"TestPing","Elapsed":0}`,
+			},
+			wantCases:  []CaseResult{{Name: "TestPing", Passed: true, Points: 1}},
+			wantPassed: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := scoreGoTests("TEST_001", "Core", []byte(strings.Join(tc.events, "\n")+"\n"), tc.stderr)
+
+			if result.ID != "TEST_001" || result.Title != "Core" {
+				t.Errorf("Expected ID TEST_001 titled Core, got %q titled %q", result.ID, result.Title)
+			}
+			if result.Passed != tc.wantPassed || result.Failed != tc.wantFailed {
+				t.Errorf("Expected %d passed and %d failed, got %d and %d", tc.wantPassed, tc.wantFailed, result.Passed, result.Failed)
+			}
+			if result.Score != tc.wantPassed || result.Max != len(tc.wantCases) {
+				t.Errorf("Expected a score of %d/%d, got %d/%d", tc.wantPassed, len(tc.wantCases), result.Score, result.Max)
+			}
+			if len(result.Cases) != len(tc.wantCases) {
+				t.Fatalf("Expected %d cases, got %+v", len(tc.wantCases), result.Cases)
+			}
+			for i, want := range tc.wantCases {
+				if result.Cases[i] != want {
+					t.Errorf("Expected case %d to be %+v, got %+v", i, want, result.Cases[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package challenge
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Summary is the score sheet for a whole validation run
+type Summary struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Target      string    `json:"target"`
+	Score       int       `json:"score"`
+	Max         int       `json:"max"`
+	Percent     float64   `json:"percent"`
+	Passed      int       `json:"passed"`
+	Failed      int       `json:"failed"`
+	Results     []Result  `json:"challenges"`
+}
+
+// NewSummary totals the results of a run against target
+func NewSummary(target string, results []Result) Summary {
+	s := Summary{GeneratedAt: time.Now().UTC(), Target: target, Results: results}
+	for _, r := range results {
+		s.Score += r.Score
+		s.Max += r.Max
+		s.Passed += r.Passed
+		s.Failed += r.Failed
+	}
+	s.Percent = percent(s.Score, s.Max)
+	return s
+}
+
+// WriteJSON writes the summary as indented JSON
+func (s Summary) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Cases      []junitCase     `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the summary as JUnit XML, one test suite per challenge with its score as properties
+func (s Summary) WriteJUnit(w io.Writer) error {
+	suites := junitSuites{Name: "validation", Tests: s.Passed + s.Failed, Failures: s.Failed}
+	var total float64
+	for _, r := range s.Results {
+		suite := junitSuite{
+			Name:      r.ID,
+			Tests:     r.Passed + r.Failed,
+			Failures:  r.Failed,
+			Time:      seconds(r.Seconds),
+			Timestamp: s.GeneratedAt.Format(time.RFC3339),
+			Properties: []junitProperty{
+				{Name: "title", Value: r.Title},
+				{Name: "score", Value: fmt.Sprint(r.Score)},
+				{Name: "max_score", Value: fmt.Sprint(r.Max)},
+				{Name: "percent", Value: fmt.Sprint(r.Percent)},
+			},
+		}
+		for _, c := range r.Cases {
+			jc := junitCase{Name: c.Name, Classname: r.ID, Time: seconds(c.Seconds)}
+			if !c.Passed {
+				jc.Failure = &junitFailure{Message: c.Failure, Text: c.Failure}
+			}
+			suite.Cases = append(suite.Cases, jc)
+		}
+		suites.Suites = append(suites.Suites, suite)
+		total += r.Seconds
+	}
+	suites.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
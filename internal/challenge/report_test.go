@@ -0,0 +1,105 @@
+package challenge
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func testSummary() Summary {
+	var core Result
+	core.ID, core.Title = "TEST_001", "Core & <arithmetic>"
+	core.add(CaseResult{Name: "add", Passed: true, Points: 3, Seconds: 0.25})
+	core.add(CaseResult{Name: "sub", Points: 1, Seconds: 0.5, Failure: `status 400, want 200 (body "bad")`})
+
+	var eval Result
+	eval.ID, eval.Title = "TEST_002", "Eval"
+	eval.add(CaseResult{Name: "eval", Passed: true, Points: 1, Seconds: 0.125})
+
+	s := NewSummary("http://localhost:8080", []Result{core, eval})
+	s.GeneratedAt = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return s
+}
+
+func TestNewSummary(t *testing.T) {
+	s := testSummary()
+	if s.Score != 4 || s.Max != 5 || s.Percent != 80 || s.Passed != 2 || s.Failed != 1 {
+		t.Errorf("Expected 4/5 (80%%) with 2 passed and 1 failed, got %d/%d (%v%%), %d passed, %d failed",
+			s.Score, s.Max, s.Percent, s.Passed, s.Failed)
+	}
+	if core := s.Results[0]; core.Percent != 75 || core.Seconds != 0.75 {
+		t.Errorf("Expected TEST_001 at 75%% in 0.75s, got %v%% in %vs", core.Percent, core.Seconds)
+	}
+	if empty := NewSummary("", nil); empty.Percent != 0 {
+		t.Errorf("Expected an empty run to score 0%%, got %v%%", empty.Percent)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testSummary().WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	var got Summary
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, buf.String())
+	}
+	if got.Target != "http://localhost:8080" || got.Score != 4 || len(got.Results) != 2 || len(got.Results[0].Cases) != 2 {
+		t.Errorf("Expected the summary to round-trip, got %+v", got)
+	}
+	if failure := got.Results[0].Cases[1].Failure; failure != `status 400, want 200 (body "bad")` {
+		t.Errorf("Expected the failure message to round-trip, got %q", failure)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testSummary().WriteJUnit(&buf); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte(xml.Header)) {
+		t.Errorf("Expected an XML declaration, got %q", buf.String())
+	}
+
+	var got junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Expected valid XML, got %v: %s", err, buf.String())
+	}
+	if got.Name != "validation" || got.Tests != 3 || got.Failures != 1 || got.Time != "0.875" {
+		t.Errorf("Expected 3 tests, 1 failure in 0.875s, got %d tests, %d failures in %ss", got.Tests, got.Failures, got.Time)
+	}
+	if len(got.Suites) != 2 {
+		t.Fatalf("Expected a suite per challenge, got %d", len(got.Suites))
+	}
+
+	core := got.Suites[0]
+	if core.Name != "TEST_001" || core.Tests != 2 || core.Failures != 1 || core.Time != "0.750" ||
+		core.Timestamp != "2024-05-01T12:00:00Z" {
+		t.Errorf("Expected TEST_001 with 2 tests, 1 failure in 0.750s at 2024-05-01T12:00:00Z, got %+v", core)
+	}
+	wantProperties := []junitProperty{
+		{Name: "title", Value: "Core & <arithmetic>"},
+		{Name: "score", Value: "3"},
+		{Name: "max_score", Value: "4"},
+		{Name: "percent", Value: "75"},
+	}
+	if len(core.Properties) != len(wantProperties) {
+		t.Fatalf("Expected properties %+v, got %+v", wantProperties, core.Properties)
+	}
+	for i, want := range wantProperties {
+		if core.Properties[i] != want {
+			t.Errorf("Expected property %+v, got %+v", want, core.Properties[i])
+		}
+	}
+
+	if passed := core.Cases[0]; passed.Name != "add" || passed.Classname != "TEST_001" || passed.Time != "0.250" || passed.Failure != nil {
+		t.Errorf("Expected a passing 'add' case in TEST_001, got %+v", passed)
+	}
+	failed := core.Cases[1]
+	if failed.Failure == nil || failed.Failure.Message != `status 400, want 200 (body "bad")` || failed.Failure.Text != failed.Failure.Message {
+		t.Errorf("Expected the 'sub' case to carry its failure, got %+v", failed.Failure)
+	}
+}
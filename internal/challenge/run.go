@@ -0,0 +1,175 @@
+package challenge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CaseResult is the outcome of one case
+type CaseResult struct {
+	Name    string  `json:"name"`
+	Passed  bool    `json:"passed"`
+	Points  int     `json:"points"`
+	Seconds float64 `json:"seconds"`
+	// Failure explains why the case failed
+	Failure string `json:"failure,omitempty"`
+}
+
+// Result is the scored outcome of a challenge or test suite
+type Result struct {
+	ID      string       `json:"id"`
+	Title   string       `json:"title"`
+	Score   int          `json:"score"`
+	Max     int          `json:"max"`
+	Percent float64      `json:"percent"`
+	Passed  int          `json:"passed"`
+	Failed  int          `json:"failed"`
+	Seconds float64      `json:"seconds"`
+	Cases   []CaseResult `json:"cases"`
+}
+
+// add records a case and updates the totals
+func (r *Result) add(c CaseResult) {
+	r.Cases = append(r.Cases, c)
+	r.Max += c.Points
+	r.Seconds += c.Seconds
+	if c.Passed {
+		r.Passed++
+		r.Score += c.Points
+	} else {
+		r.Failed++
+	}
+	r.Percent = percent(r.Score, r.Max)
+}
+
+func percent(score, max int) float64 {
+	if max == 0 {
+		return 0
+	}
+	return math.Round(1000*float64(score)/float64(max)) / 10
+}
+
+// Run sends every case in ch to baseURL and scores the responses
+func Run(ctx context.Context, client *http.Client, baseURL string, ch Challenge) Result {
+	result := Result{ID: ch.ID, Title: ch.Title, Cases: make([]CaseResult, 0, len(ch.Cases))}
+
+	for _, c := range ch.Cases {
+		start := time.Now()
+		err := runCase(ctx, client, baseURL, c)
+
+		res := CaseResult{Name: c.Name, Points: max(c.Points, 1), Passed: err == nil, Seconds: time.Since(start).Seconds()}
+		if err != nil {
+			res.Failure = err.Error()
+		}
+		result.add(res)
+	}
+
+	return result
+}
+
+func runCase(ctx context.Context, client *http.Client, baseURL string, c Case) error {
+	method := c.Request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	target := baseURL + c.Request.Path
+	if len(c.Request.Query) > 0 {
+		query := url.Values{}
+		for name, value := range c.Request.Query {
+			query.Set(name, value)
+		}
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, strings.NewReader(c.Request.Body))
+	if err != nil {
+		return err
+	}
+	for name, value := range c.Request.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+
+	return check(c.Expect, resp, string(body))
+}
+
+func check(want Expect, resp *http.Response, body string) error {
+	if want.Status != 0 && resp.StatusCode != want.Status {
+		return fmt.Errorf("status %d, want %d (body %q)", resp.StatusCode, want.Status, body)
+	}
+	if want.StatusNot != 0 && resp.StatusCode == want.StatusNot {
+		return fmt.Errorf("status %d, want anything else", resp.StatusCode)
+	}
+	if want.ContentType != "" {
+		mediatype, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		if mediatype != want.ContentType {
+			return fmt.Errorf("content type %q, want %q", resp.Header.Get("Content-Type"), want.ContentType)
+		}
+	}
+	if want.Body != "" && body != want.Body {
+		return fmt.Errorf("body %q, want %q", body, want.Body)
+	}
+	if want.NonEmpty && body == "" {
+		return fmt.Errorf("empty body, want a message")
+	}
+	if want.Number != nil {
+		got, err := strconv.ParseFloat(strings.TrimSpace(body), 64)
+		if err != nil {
+			return fmt.Errorf("body %q is not a number", body)
+		}
+		if math.Abs(got-*want.Number) > want.Tolerance {
+			return fmt.Errorf("got %v, want %v ± %v", got, *want.Number, want.Tolerance)
+		}
+	}
+	if len(want.JSON) > 0 {
+		var expected, got any
+		if err := json.Unmarshal(want.JSON, &expected); err != nil {
+			return fmt.Errorf("invalid expected json: %w", err)
+		}
+		if err := json.Unmarshal([]byte(body), &got); err != nil {
+			return fmt.Errorf("body %q is not JSON", body)
+		}
+		if !containsJSON(got, expected) {
+			return fmt.Errorf("body %s does not contain %s", body, want.JSON)
+		}
+	}
+	return nil
+}
+
+// containsJSON reports whether got has every field of want with an equal value, recursing into objects
+func containsJSON(got, want any) bool {
+	wantObj, ok := want.(map[string]any)
+	if !ok {
+		return reflect.DeepEqual(got, want)
+	}
+	gotObj, ok := got.(map[string]any)
+	if !ok {
+		return false
+	}
+	for key, value := range wantObj {
+		if !containsJSON(gotObj[key], value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package challenge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContainsJSON(t *testing.T) {
+	testCases := []struct {
+		name string
+		got  string
+		want string
+		ok   bool
+	}{
+		{name: "equal objects", got: `{"result":5}`, want: `{"result":5}`, ok: true},
+		{name: "extra fields ignored", got: `{"operation":"add","a":2,"b":3,"result":5}`, want: `{"result":5}`, ok: true},
+		{name: "numbers by value", got: `{"result":5.0}`, want: `{"result":5}`, ok: true},
+		{name: "nested subset", got: `{"status":400,"errors":{"a":"bad","b":"bad"}}`, want: `{"errors":{"a":"bad"}}`, ok: true},
+		{name: "empty want", got: `{"result":5}`, want: `{}`, ok: true},
+		{name: "different value", got: `{"result":5}`, want: `{"result":6}`},
+		{name: "missing field", got: `{"result":5}`, want: `{"operation":"add"}`},
+		{name: "different type", got: `{"result":"5"}`, want: `{"result":5}`},
+		{name: "object wanted, scalar got", got: `{"errors":"bad"}`, want: `{"errors":{"a":"bad"}}`},
+		{name: "arrays must match exactly", got: `{"values":[1,2,3]}`, want: `{"values":[1,2]}`},
+		{name: "equal arrays", got: `[1,2,3]`, want: `[1,2,3]`, ok: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got, want any
+			if err := json.Unmarshal([]byte(tc.got), &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if ok := containsJSON(got, want); ok != tc.ok {
+				t.Errorf("containsJSON(%s, %s) = %v, want %v", tc.got, tc.want, ok, tc.ok)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	five := 5.0
+
+	testCases := []struct {
+		name        string
+		expect      Expect
+		status      int
+		contentType string
+		body        string
+		wantErr     string
+	}{
+		{name: "no checks", body: "anything"},
+		{name: "status", expect: Expect{Status: 200}, status: 200, body: "5.00"},
+		{name: "wrong status", expect: Expect{Status: 200}, status: 400, body: "bad", wantErr: `status 400, want 200 (body "bad")`},
+		{name: "status not", expect: Expect{StatusNot: 500}, status: 400},
+		{name: "status not matched", expect: Expect{StatusNot: 500}, status: 500, wantErr: "status 500, want anything else"},
+		{name: "content type ignores parameters", expect: Expect{ContentType: "text/plain"}, contentType: "text/plain; charset=utf-8"},
+		{name: "wrong content type", expect: Expect{ContentType: "application/json"}, contentType: "text/plain",
+			wantErr: `content type "text/plain", want "application/json"`},
+		{name: "exact body", expect: Expect{Body: "5.00"}, body: "5.00"},
+		{name: "wrong body", expect: Expect{Body: "5.00"}, body: "5", wantErr: `body "5", want "5.00"`},
+		{name: "non-empty", expect: Expect{NonEmpty: true}, body: "bad operand"},
+		{name: "empty", expect: Expect{NonEmpty: true}, wantErr: "empty body, want a message"},
+		{name: "number within tolerance", expect: Expect{Number: &five, Tolerance: 0.01}, body: "5.004\n"},
+		{name: "number outside tolerance", expect: Expect{Number: &five, Tolerance: 0.01}, body: "5.02", wantErr: "got 5.02, want 5 ± 0.01"},
+		{name: "zero tolerance is exact", expect: Expect{Number: &five}, body: "5.00"},
+		{name: "not a number", expect: Expect{Number: &five}, body: "five", wantErr: `body "five" is not a number`},
+		{name: "JSON contained", expect: Expect{JSON: json.RawMessage(`{"result":5}`)}, body: `{"operation":"add","result":5}`},
+		{name: "JSON not contained", expect: Expect{JSON: json.RawMessage(`{"result":6}`)}, body: `{"result":5}`,
+			wantErr: `body {"result":5} does not contain {"result":6}`},
+		{name: "body not JSON", expect: Expect{JSON: json.RawMessage(`{"result":5}`)}, body: "result: 5", wantErr: `body "result: 5" is not JSON`},
+		{name: "invalid expected JSON", expect: Expect{JSON: json.RawMessage(`{result}`)}, body: `{}`, wantErr: "invalid expected json"},
+		{name: "status checked first", expect: Expect{Status: 200, Body: "5.00"}, status: 404, body: "not found",
+			wantErr: `status 404, want 200 (body "not found")`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+			if tc.contentType != "" {
+				resp.Header.Set("Content-Type", tc.contentType)
+			}
+
+			err := check(tc.expect, resp, tc.body)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("Expected no error, got %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.wantErr)):
+				t.Errorf("Expected an error starting %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunScores(t *testing.T) {
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte("5.00"))
+	}))
+	defer srv.Close()
+
+	ch := Challenge{
+		ID: "TEST_000",
+		Cases: []Case{
+			{Name: "sends query and headers", Request: Request{Path: "/add", Query: map[string]string{"a": "2", "b": "3"},
+				Headers: map[string]string{"Accept": "text/plain"}}, Expect: Expect{Status: 200, Body: "5.00"}, Points: 3},
+			{Name: "fails", Request: Request{Path: "/add"}, Expect: Expect{Status: 400}},
+		},
+	}
+	result := Run(context.Background(), srv.Client(), srv.URL, ch)
+
+	if len(requests) != len(ch.Cases) {
+		t.Fatalf("Expected %d requests, got %d", len(ch.Cases), len(requests))
+	}
+	if got := requests[0]; got.URL.RawQuery != "a=2&b=3" || got.Header.Get("Accept") != "text/plain" || got.Method != http.MethodGet {
+		t.Errorf("Expected GET /add?a=2&b=3 with Accept text/plain, got %s %s with Accept %q",
+			requests[0].Method, requests[0].URL, requests[0].Header.Get("Accept"))
+	}
+	if result.Score != 3 || result.Max != 4 || result.Percent != 75 || result.Passed != 1 || result.Failed != 1 {
+		t.Errorf("Expected 3/4 (75%%) with one pass and one failure, got %d/%d (%v%%), %d passed, %d failed",
+			result.Score, result.Max, result.Percent, result.Passed, result.Failed)
+	}
+	if result.Cases[1].Points != 1 || result.Cases[1].Failure == "" {
+		t.Errorf("Expected the unweighted failing case to score out of 1 with a failure, got %+v", result.Cases[1])
+	}
+}
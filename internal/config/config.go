@@ -0,0 +1,245 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix prefixes every environment variable the server reads
+const EnvPrefix = "TECHTEST_"
+
+// Config holds everything needed to start the server.
+// Values are resolved in increasing order of precedence: defaults, config file, environment, flags.
+type Config struct {
+	Addr          string   `json:"addr"`
+	Operations    []string `json:"operations"`
+	DecimalPlaces int      `json:"decimal_places"`
+	Precision     string   `json:"precision"`
+	ReadTimeout   Duration `json:"read_timeout"`
+	WriteTimeout  Duration `json:"write_timeout"`
+	IdleTimeout   Duration `json:"idle_timeout"`
+	// ShutdownDelay is how long /readyz reports not ready before the server stops accepting connections
+	ShutdownDelay Duration `json:"shutdown_delay"`
+	// ShutdownTimeout bounds how long in-flight requests may drain after SIGINT or SIGTERM
+	ShutdownTimeout Duration   `json:"shutdown_timeout"`
+	LogLevel        slog.Level `json:"log_level"`
+	// SelfTestVectors is a JSON file of golden vectors; empty uses the built-in set
+	SelfTestVectors string `json:"selftest_vectors"`
+	// SelfTestMode decides what a failing startup self-test does: "fail" refuses to start, "unready" starts unready
+	SelfTestMode string `json:"selftest_mode"`
+	// RecordFile receives every request and response as JSON lines for cmd/replay; empty disables recording
+	RecordFile string `json:"record_file"`
+}
+
+// Default returns the configuration used when nothing overrides it
+func Default() Config {
+	return Config{
+		Addr:            ":8080",
+		DecimalPlaces:   2,
+		Precision:       "float",
+		ReadTimeout:     Duration(5 * time.Second),
+		WriteTimeout:    Duration(10 * time.Second),
+		IdleTimeout:     Duration(60 * time.Second),
+		ShutdownTimeout: Duration(15 * time.Second),
+		LogLevel:        slog.LevelInfo,
+		SelfTestMode:    "fail",
+	}
+}
+
+// Load resolves the configuration from a JSON config file, environment variables and command-line args.
+// The config file is named by the -config flag or the TECHTEST_CONFIG variable.
+func Load(args []string, getenv func(string) string) (Config, error) {
+	cfg := Default()
+	def := Default()
+
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to a JSON config file")
+	// Flag defaults are only shown in usage; unset flags never override the file or environment
+	addr := fs.String("addr", def.Addr, "listen address")
+	operations := fs.String("operations", "", "comma-separated operations to enable (default all)")
+	decimalPlaces := fs.Int("decimal-places", def.DecimalPlaces, "decimal places in plain-text results")
+	precision := fs.String("precision", def.Precision, "default precision: float, exact, fraction or complex")
+	readTimeout := fs.Duration("read-timeout", time.Duration(def.ReadTimeout), "maximum duration for reading a request")
+	writeTimeout := fs.Duration("write-timeout", time.Duration(def.WriteTimeout), "maximum duration for writing a response")
+	idleTimeout := fs.Duration("idle-timeout", time.Duration(def.IdleTimeout), "maximum keep-alive idle duration")
+	shutdownDelay := fs.Duration("shutdown-delay", time.Duration(def.ShutdownDelay), "how long to report not ready before draining on shutdown")
+	shutdownTimeout := fs.Duration("shutdown-timeout", time.Duration(def.ShutdownTimeout), "maximum duration to drain in-flight requests on shutdown")
+	selfTestVectors := fs.String("selftest-vectors", def.SelfTestVectors, "JSON file of self-test vectors (default built-in)")
+	selfTestMode := fs.String("selftest-mode", def.SelfTestMode, "on self-test failure: fail (refuse to start) or unready")
+	recordFile := fs.String("record-file", def.RecordFile, "append every request and response to this JSON lines file")
+	logLevel := fs.String("log-level", def.LogLevel.String(), "log level: debug, info, warn or error")
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	if *configPath == "" {
+		*configPath = getenv(EnvPrefix + "CONFIG")
+	}
+	if *configPath != "" {
+		if err := loadFile(&cfg, *configPath); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := applyEnv(&cfg, getenv); err != nil {
+		return Config{}, err
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		if flagErr != nil {
+			return
+		}
+		switch f.Name {
+		case "addr":
+			cfg.Addr = *addr
+		case "operations":
+			cfg.Operations = splitList(*operations)
+		case "decimal-places":
+			cfg.DecimalPlaces = *decimalPlaces
+		case "precision":
+			cfg.Precision = *precision
+		case "read-timeout":
+			cfg.ReadTimeout = Duration(*readTimeout)
+		case "write-timeout":
+			cfg.WriteTimeout = Duration(*writeTimeout)
+		case "idle-timeout":
+			cfg.IdleTimeout = Duration(*idleTimeout)
+		case "shutdown-delay":
+			cfg.ShutdownDelay = Duration(*shutdownDelay)
+		case "shutdown-timeout":
+			cfg.ShutdownTimeout = Duration(*shutdownTimeout)
+		case "selftest-vectors":
+			cfg.SelfTestVectors = *selfTestVectors
+		case "selftest-mode":
+			cfg.SelfTestMode = *selfTestMode
+		case "record-file":
+			cfg.RecordFile = *recordFile
+		case "log-level":
+			flagErr = cfg.LogLevel.UnmarshalText([]byte(*logLevel))
+		}
+	})
+	if flagErr != nil {
+		return Config{}, fmt.Errorf("invalid -log-level: %w", flagErr)
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Validate checks values that cannot be rejected while parsing
+func (c Config) Validate() error {
+	if c.Addr == "" {
+		return errors.New("addr must not be empty")
+	}
+	if c.DecimalPlaces < 0 || c.DecimalPlaces > 17 {
+		return fmt.Errorf("decimal_places must be between 0 and 17, got %d", c.DecimalPlaces)
+	}
+	if c.ReadTimeout < 0 || c.WriteTimeout < 0 || c.IdleTimeout < 0 || c.ShutdownDelay < 0 || c.ShutdownTimeout < 0 {
+		return errors.New("timeouts must not be negative")
+	}
+	if c.SelfTestMode != "fail" && c.SelfTestMode != "unready" {
+		return fmt.Errorf("selftest_mode must be \"fail\" or \"unready\", got %q", c.SelfTestMode)
+	}
+	return nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func applyEnv(cfg *Config, getenv func(string) string) error {
+	if v := getenv(EnvPrefix + "ADDR"); v != "" {
+		cfg.Addr = v
+	}
+	if v := getenv(EnvPrefix + "OPERATIONS"); v != "" {
+		cfg.Operations = splitList(v)
+	}
+	if v := getenv(EnvPrefix + "DECIMAL_PLACES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %sDECIMAL_PLACES: %w", EnvPrefix, err)
+		}
+		cfg.DecimalPlaces = n
+	}
+	if v := getenv(EnvPrefix + "PRECISION"); v != "" {
+		cfg.Precision = v
+	}
+	for name, target := range map[string]*Duration{
+		"READ_TIMEOUT":     &cfg.ReadTimeout,
+		"WRITE_TIMEOUT":    &cfg.WriteTimeout,
+		"IDLE_TIMEOUT":     &cfg.IdleTimeout,
+		"SHUTDOWN_DELAY":   &cfg.ShutdownDelay,
+		"SHUTDOWN_TIMEOUT": &cfg.ShutdownTimeout,
+	} {
+		if v := getenv(EnvPrefix + name); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s%s: %w", EnvPrefix, name, err)
+			}
+			*target = Duration(d)
+		}
+	}
+	if v := getenv(EnvPrefix + "SELFTEST_VECTORS"); v != "" {
+		cfg.SelfTestVectors = v
+	}
+	if v := getenv(EnvPrefix + "SELFTEST_MODE"); v != "" {
+		cfg.SelfTestMode = v
+	}
+	if v := getenv(EnvPrefix + "RECORD_FILE"); v != "" {
+		cfg.RecordFile = v
+	}
+	if v := getenv(EnvPrefix + "LOG_LEVEL"); v != "" {
+		if err := cfg.LogLevel.UnmarshalText([]byte(v)); err != nil {
+			return fmt.Errorf("invalid %sLOG_LEVEL: %w", EnvPrefix, err)
+		}
+	}
+	return nil
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Duration is a time.Duration that reads and writes JSON as a string such as "5s"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLoadPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(file, []byte(`{"addr":":1000","decimal_places":4,"operations":["add","sub"],"read_timeout":"7s"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want func(Config) bool
+	}{
+		{"defaults", nil, nil, func(c Config) bool {
+			return c.Addr == ":8080" && c.DecimalPlaces == 2 && c.Operations == nil && c.SelfTestMode == "fail"
+		}},
+		{"file over defaults", []string{"-config", file}, nil, func(c Config) bool {
+			return c.Addr == ":1000" && c.DecimalPlaces == 4 && slices.Equal(c.Operations, []string{"add", "sub"}) &&
+				time.Duration(c.ReadTimeout) == 7*time.Second && c.Precision == "float"
+		}},
+		{"file named by environment", nil, map[string]string{"TECHTEST_CONFIG": file}, func(c Config) bool {
+			return c.Addr == ":1000"
+		}},
+		{"environment over file", []string{"-config", file}, map[string]string{"TECHTEST_ADDR": ":2000", "TECHTEST_OPERATIONS": "mul, div"}, func(c Config) bool {
+			return c.Addr == ":2000" && slices.Equal(c.Operations, []string{"mul", "div"}) && c.DecimalPlaces == 4
+		}},
+		{"flags over environment", []string{"-config", file, "-addr", ":3000", "-read-timeout", "1s"}, map[string]string{"TECHTEST_ADDR": ":2000", "TECHTEST_READ_TIMEOUT": "9s"}, func(c Config) bool {
+			return c.Addr == ":3000" && time.Duration(c.ReadTimeout) == time.Second && c.DecimalPlaces == 4
+		}},
+		{"unset flags keep environment", []string{"-decimal-places", "3"}, map[string]string{"TECHTEST_ADDR": ":2000"}, func(c Config) bool {
+			return c.Addr == ":2000" && c.DecimalPlaces == 3
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := Load(tc.args, func(key string) string { return tc.env[key] })
+			if err != nil {
+				t.Fatalf("Load returned error: %v", err)
+			}
+			if !tc.want(cfg) {
+				t.Errorf("unexpected config: %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	unknownKey := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(unknownKey, []byte(`{"adr":":1000"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name string
+		args []string
+		env  map[string]string
+	}{
+		{"unknown file key", []string{"-config", unknownKey}, nil},
+		{"missing file", []string{"-config", filepath.Join(t.TempDir(), "missing.json")}, nil},
+		{"bad environment number", nil, map[string]string{"TECHTEST_DECIMAL_PLACES": "two"}},
+		{"bad environment duration", nil, map[string]string{"TECHTEST_IDLE_TIMEOUT": "soon"}},
+		{"bad log level flag", []string{"-log-level", "loud"}, nil},
+		{"decimal places out of range", []string{"-decimal-places", "18"}, nil},
+		{"negative timeout", nil, map[string]string{"TECHTEST_SHUTDOWN_TIMEOUT": "-1s"}},
+		{"unknown self-test mode", []string{"-selftest-mode", "ignore"}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Load(tc.args, func(key string) string { return tc.env[key] }); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 package domain
 
-func (m *mathService) Add(a, b float64) float64 {
-	return a + b
+func (m *mathService) Add(a, b float64) (float64, error) {
+	return checkResult(a + b)
 }
@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// ComplexMathService mirrors MathService over complex128. Multi-valued functions such as Sqrt, Log and the inverse
+// trigonometric functions return their principal value, so they are defined for every argument but a few poles.
+type ComplexMathService interface {
+	Add(a, b complex128) (complex128, error)
+	Subtract(a, b complex128) (complex128, error)
+	Multiply(a, b complex128) (complex128, error)
+	Divide(a, b complex128) (complex128, error)
+	Sum(values []complex128) (complex128, error)
+	Product(values []complex128) (complex128, error)
+	Pow(z, w complex128) (complex128, error)
+	Sqrt(z complex128) (complex128, error)
+	NthRoot(z, n complex128) (complex128, error)
+	Exp(z complex128) (complex128, error)
+	Log(z complex128) (complex128, error)
+	Log10(z complex128) (complex128, error)
+	Log2(z complex128) (complex128, error)
+	Sin(z complex128) (complex128, error)
+	Cos(z complex128) (complex128, error)
+	Tan(z complex128) (complex128, error)
+	Asin(z complex128) (complex128, error)
+	Acos(z complex128) (complex128, error)
+	Atan(z complex128) (complex128, error)
+	// Abs returns the modulus |z|
+	Abs(z complex128) (float64, error)
+	// Arg returns the argument of z in (-π, π]
+	Arg(z complex128) (float64, error)
+	Conj(z complex128) (complex128, error)
+	// Polar returns the modulus and argument of z, the argument in unit
+	Polar(z complex128, unit AngleUnit) (r, theta float64, err error)
+	// Rect returns the complex number with modulus r and argument theta, given in unit
+	Rect(r, theta float64, unit AngleUnit) (complex128, error)
+}
+
+type complexMathService struct {
+	math MathService
+}
+
+// NewComplexMathService returns a ComplexMathService backed by math
+func NewComplexMathService(math MathService) ComplexMathService {
+	return &complexMathService{math: math}
+}
+
+// checkComplex turns results with a non-finite part into domain errors
+func checkComplex(z complex128) (complex128, error) {
+	switch {
+	case cmplx.IsNaN(z):
+		return 0, ErrNotANumber
+	case cmplx.IsInf(z):
+		return 0, ErrOverflow
+	default:
+		return z, nil
+	}
+}
+
+func (m *complexMathService) Add(a, b complex128) (complex128, error) {
+	return checkComplex(a + b)
+}
+
+// Subtract performs subtraction of b from a (a - b)
+func (m *complexMathService) Subtract(a, b complex128) (complex128, error) {
+	return checkComplex(a - b)
+}
+
+func (m *complexMathService) Multiply(a, b complex128) (complex128, error) {
+	return checkComplex(a * b)
+}
+
+// Divide performs division of a by b (a / b)
+func (m *complexMathService) Divide(a, b complex128) (complex128, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return checkComplex(a / b)
+}
+
+func (m *complexMathService) Sum(values []complex128) (complex128, error) {
+	re := make([]float64, len(values))
+	im := make([]float64, len(values))
+	for i, v := range values {
+		re[i], im[i] = real(v), imag(v)
+	}
+	reSum, err := m.math.Sum(re)
+	if err != nil {
+		return 0, err
+	}
+	imSum, err := m.math.Sum(im)
+	if err != nil {
+		return 0, err
+	}
+	return complex(reSum, imSum), nil
+}
+
+func (m *complexMathService) Product(values []complex128) (complex128, error) {
+	product := complex128(1)
+	for _, v := range values {
+		product *= v
+		if _, err := checkComplex(product); err != nil {
+			return 0, err
+		}
+	}
+	return product, nil
+}
+
+// Pow raises z to the power w. Zero to a power with a negative or non-zero imaginary part is a division by zero.
+func (m *complexMathService) Pow(z, w complex128) (complex128, error) {
+	if z == 0 && w != 0 && (real(w) < 0 || imag(w) != 0) {
+		return 0, ErrDivisionByZero
+	}
+	return checkComplex(cmplx.Pow(z, w))
+}
+
+func (m *complexMathService) Sqrt(z complex128) (complex128, error) {
+	return checkComplex(cmplx.Sqrt(z))
+}
+
+// NthRoot returns the principal nth root of z
+func (m *complexMathService) NthRoot(z, n complex128) (complex128, error) {
+	if n == 0 {
+		return 0, ErrOutOfDomain
+	}
+	return m.Pow(z, 1/n)
+}
+
+func (m *complexMathService) Exp(z complex128) (complex128, error) {
+	return checkComplex(cmplx.Exp(z))
+}
+
+func (m *complexMathService) Log(z complex128) (complex128, error) {
+	if z == 0 {
+		return 0, ErrLogNonPositive
+	}
+	return checkComplex(cmplx.Log(z))
+}
+
+func (m *complexMathService) Log10(z complex128) (complex128, error) {
+	if z == 0 {
+		return 0, ErrLogNonPositive
+	}
+	return checkComplex(cmplx.Log10(z))
+}
+
+func (m *complexMathService) Log2(z complex128) (complex128, error) {
+	if z == 0 {
+		return 0, ErrLogNonPositive
+	}
+	return checkComplex(cmplx.Log(z) / math.Ln2)
+}
+
+func (m *complexMathService) Sin(z complex128) (complex128, error) {
+	return checkComplex(cmplx.Sin(z))
+}
+
+func (m *complexMathService) Cos(z complex128) (complex128, error) {
+	return checkComplex(cmplx.Cos(z))
+}
+
+func (m *complexMathService) Tan(z complex128) (complex128, error) {
+	return checkComplex(cmplx.Tan(z))
+}
+
+func (m *complexMathService) Asin(z complex128) (complex128, error) {
+	return checkComplex(cmplx.Asin(z))
+}
+
+func (m *complexMathService) Acos(z complex128) (complex128, error) {
+	return checkComplex(cmplx.Acos(z))
+}
+
+// Atan returns the principal arctangent of z, which has poles at ±i
+func (m *complexMathService) Atan(z complex128) (complex128, error) {
+	if z == 1i || z == -1i {
+		return 0, ErrOutOfDomain
+	}
+	return checkComplex(cmplx.Atan(z))
+}
+
+func (m *complexMathService) Abs(z complex128) (float64, error) {
+	return checkResult(cmplx.Abs(z))
+}
+
+func (m *complexMathService) Arg(z complex128) (float64, error) {
+	return checkResult(cmplx.Phase(z))
+}
+
+func (m *complexMathService) Conj(z complex128) (complex128, error) {
+	return cmplx.Conj(z), nil
+}
+
+func (m *complexMathService) Polar(z complex128, unit AngleUnit) (float64, float64, error) {
+	r, err := m.Abs(z)
+	if err != nil {
+		return 0, 0, err
+	}
+	theta, err := fromRadians(cmplx.Phase(z), unit)
+	if err != nil {
+		return 0, 0, err
+	}
+	return r, theta, nil
+}
+
+// Rect converts polar coordinates to a complex number. In degrees, multiples of 90° land exactly on an axis.
+func (m *complexMathService) Rect(r, theta float64, unit AngleUnit) (complex128, error) {
+	if unit == Degrees {
+		switch normalizeDegrees(theta) {
+		case 0:
+			return checkComplex(complex(r, 0))
+		case 90:
+			return checkComplex(complex(0, r))
+		case 180:
+			return checkComplex(complex(-r, 0))
+		case 270:
+			return checkComplex(complex(0, -r))
+		}
+	}
+	rad, err := toRadians(theta, unit)
+	if err != nil {
+		return 0, err
+	}
+	return checkComplex(cmplx.Rect(r, rad))
+}
@@ -0,0 +1,11 @@
+package domain
+
+// Divide performs division of a by b (a / b)
+func (m *mathService) Divide(a, b float64) (float64, error) {
+	m.logger.Debug("divide", "a", a, "b", b)
+
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return checkResult(a / b)
+}
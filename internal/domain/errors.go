@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+// Domain errors report well-formed inputs for which an operation has no finite result
+var (
+	ErrDivisionByZero = errors.New("division by zero")
+	ErrOverflow       = errors.New("result overflows the range of a 64-bit float")
+	ErrNotANumber     = errors.New("result is not a number")
+	ErrNegativeRoot   = errors.New("even root of a negative number")
+	ErrLogNonPositive = errors.New("logarithm of zero or a negative number")
+	ErrOutOfDomain    = errors.New("argument is outside the domain of the function")
+)
+
+var domainErrors = []error{
+	ErrDivisionByZero, ErrOverflow, ErrNotANumber, ErrNegativeRoot, ErrLogNonPositive, ErrOutOfDomain,
+	ErrEmptyDataset, ErrTooFewValues, ErrNoSignChange, ErrNoConvergence,
+}
+
+// IsDomainError reports whether err wraps one of the domain errors
+func IsDomainError(err error) bool {
+	for _, target := range domainErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkResult turns non-finite float results into domain errors
+func checkResult(v float64) (float64, error) {
+	switch {
+	case math.IsNaN(v):
+		return 0, ErrNotANumber
+	case math.IsInf(v, 0):
+		return 0, ErrOverflow
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ExprError reports a problem with an expression and the 1-based column it occurred at
+type ExprError struct {
+	Column  int
+	Message string
+}
+
+func (e *ExprError) Error() string {
+	return fmt.Sprintf("invalid expression at column %d: %s", e.Column, e.Message)
+}
+
+// Evaluate parses and evaluates an infix arithmetic expression.
+// Supported syntax: numbers, variables bound in vars, + - * /, unary minus and parentheses.
+// Arithmetic is delegated to the service's own operations.
+// Syntax problems are reported as *ExprError; arithmetic failures as domain errors.
+func (m *mathService) Evaluate(expr string, vars map[string]float64) (float64, error) {
+	m.logger.Debug("evaluate", "expr", expr, "vars", vars)
+
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return 0, err
+	}
+
+	p := &exprParser{tokens: tokens, vars: vars, math: m}
+	result, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return 0, &ExprError{Column: tok.column, Message: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+
+	return result, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		column := i + 1
+
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '+' || c == '-' || c == '*' || c == '/':
+			tokens = append(tokens, token{kind: tokenOperator, text: string(c), column: column})
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", column: column})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", column: column})
+			i++
+		case isDigit(c) || c == '.':
+			start := i
+			for i < len(expr) && (isDigit(expr[i]) || expr[i] == '.') {
+				i++
+			}
+			if i < len(expr) && (expr[i] == 'e' || expr[i] == 'E') {
+				j := i + 1
+				if j < len(expr) && (expr[j] == '+' || expr[j] == '-') {
+					j++
+				}
+				if j < len(expr) && isDigit(expr[j]) {
+					for j < len(expr) && isDigit(expr[j]) {
+						j++
+					}
+					i = j
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[start:i], column: column})
+		case isIdentStart(c):
+			start := i
+			for i < len(expr) && (isIdentStart(expr[i]) || isDigit(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: expr[start:i], column: column})
+		default:
+			return nil, &ExprError{Column: column, Message: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, text: "end of expression", column: len(expr) + 1})
+	return tokens, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// exprParser is a recursive descent parser that evaluates as it parses:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = ("-" | "+") unary | primary
+//	primary = number | ident | "(" expr ")"
+type exprParser struct {
+	tokens []token
+	pos    int
+	vars   map[string]float64
+	math   *mathService
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *exprParser) parseExpr() (float64, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.kind != tokenOperator || (tok.text != "+" && tok.text != "-") {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return 0, err
+		}
+
+		if tok.text == "+" {
+			left, err = p.math.Add(left, right)
+		} else {
+			left, err = p.math.Subtract(left, right)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (p *exprParser) parseTerm() (float64, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.kind != tokenOperator || (tok.text != "*" && tok.text != "/") {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+
+		if tok.text == "*" {
+			left, err = p.math.Multiply(left, right)
+		} else {
+			left, err = p.math.Divide(left, right)
+		}
+		if errors.Is(err, ErrDivisionByZero) {
+			return 0, fmt.Errorf("%w at column %d", err, tok.column)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (p *exprParser) parseUnary() (float64, error) {
+	tok := p.peek()
+	if tok.kind == tokenOperator && (tok.text == "-" || tok.text == "+") {
+		p.next()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+
+		if tok.text == "-" {
+			return p.math.Subtract(0, operand)
+		}
+		return operand, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (float64, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return 0, &ExprError{Column: tok.column, Message: fmt.Sprintf("invalid number %q", tok.text)}
+		}
+		return value, nil
+	case tokenIdent:
+		value, ok := p.vars[tok.text]
+		if !ok {
+			return 0, &ExprError{Column: tok.column, Message: fmt.Sprintf("unknown variable %q", tok.text)}
+		}
+		return value, nil
+	case tokenLParen:
+		value, err := p.parseExpr()
+		if err != nil {
+			return 0, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return 0, &ExprError{Column: closing.column, Message: fmt.Sprintf("expected ')' but found %q", closing.text)}
+		}
+		return value, nil
+	case tokenEOF:
+		return 0, &ExprError{Column: tok.column, Message: "unexpected end of expression"}
+	default:
+		return 0, &ExprError{Column: tok.column, Message: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ExactMathService mirrors MathService using arbitrary-precision rationals so results carry no binary float drift
+type ExactMathService interface {
+	Add(a, b *big.Rat) (*big.Rat, error)
+	Subtract(a, b *big.Rat) (*big.Rat, error)
+	Multiply(a, b *big.Rat) (*big.Rat, error)
+	Divide(a, b *big.Rat) (*big.Rat, error)
+	Modulo(a, b *big.Rat, mode DivisionMode) (*big.Rat, error)
+	IntDivide(a, b *big.Rat, mode DivisionMode) (*big.Rat, error)
+	Sum(values []*big.Rat) (*big.Rat, error)
+	Product(values []*big.Rat) (*big.Rat, error)
+}
+
+type exactMathService struct{}
+
+func NewExactMathService() ExactMathService {
+	return &exactMathService{}
+}
+
+func (m *exactMathService) Add(a, b *big.Rat) (*big.Rat, error) {
+	return new(big.Rat).Add(a, b), nil
+}
+
+// Subtract performs subtraction of b from a (a - b)
+func (m *exactMathService) Subtract(a, b *big.Rat) (*big.Rat, error) {
+	return new(big.Rat).Sub(a, b), nil
+}
+
+func (m *exactMathService) Multiply(a, b *big.Rat) (*big.Rat, error) {
+	return new(big.Rat).Mul(a, b), nil
+}
+
+// Divide performs division of a by b (a / b)
+func (m *exactMathService) Divide(a, b *big.Rat) (*big.Rat, error) {
+	if b.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	return new(big.Rat).Quo(a, b), nil
+}
+
+func (m *exactMathService) Modulo(a, b *big.Rat, mode DivisionMode) (*big.Rat, error) {
+	q, err := m.IntDivide(a, b, mode)
+	if err != nil {
+		return nil, err
+	}
+	// a - b*q
+	return new(big.Rat).Sub(a, new(big.Rat).Mul(b, q)), nil
+}
+
+func (m *exactMathService) IntDivide(a, b *big.Rat, mode DivisionMode) (*big.Rat, error) {
+	if b.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	quotient := new(big.Rat).Quo(a, b)
+	num, denom := quotient.Num(), quotient.Denom()
+
+	q := new(big.Int)
+	switch mode {
+	case Truncated:
+		q.Quo(num, denom)
+	case Euclidean:
+		// Denom is always positive, so Div floors; flooring a/b leaves a non-negative remainder only when b > 0
+		if b.Sign() > 0 {
+			q.Div(num, denom)
+		} else {
+			q.Neg(q.Div(new(big.Int).Neg(num), denom))
+		}
+	default:
+		return nil, fmt.Errorf("unknown division mode %q", mode)
+	}
+	return new(big.Rat).SetInt(q), nil
+}
+
+func (m *exactMathService) Sum(values []*big.Rat) (*big.Rat, error) {
+	sum := new(big.Rat)
+	for _, v := range values {
+		sum.Add(sum, v)
+	}
+	return sum, nil
+}
+
+func (m *exactMathService) Product(values []*big.Rat) (*big.Rat, error) {
+	product := big.NewRat(1, 1)
+	for _, v := range values {
+		product.Mul(product, v)
+	}
+	return product, nil
+}
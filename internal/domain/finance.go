@@ -0,0 +1,267 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+// Solver errors report cash flows without a usable internal rate of return
+var (
+	ErrNoSignChange  = errors.New("cash flows need at least one outflow and one inflow")
+	ErrNoConvergence = errors.New("no rate of return converged for these cash flows")
+)
+
+// PaymentTiming says whether annuity payments fall at the end of each period (an ordinary annuity) or the start
+// (an annuity due)
+type PaymentTiming string
+
+const (
+	EndOfPeriod   PaymentTiming = "end"
+	StartOfPeriod PaymentTiming = "begin"
+)
+
+// PaymentTimings lists every PaymentTiming; the first is the default
+var PaymentTimings = []PaymentTiming{EndOfPeriod, StartOfPeriod}
+
+// AmortizationRow is one period of a loan repayment schedule
+type AmortizationRow struct {
+	Period    int     `json:"period"`
+	Payment   float64 `json:"payment"`
+	Interest  float64 `json:"interest"`
+	Principal float64 `json:"principal"`
+	Balance   float64 `json:"balance"`
+}
+
+// FinanceService computes time-value-of-money formulas. Rates are per period, as fractions (0.05 is 5%).
+// PresentValue, FutureValue and Payment follow the spreadsheet sign convention: money paid out is negative and
+// money received positive, so together they solve pv·(1+rate)^n + pmt·(1+rate·due)·((1+rate)^n-1)/rate + fv = 0.
+type FinanceService interface {
+	PresentValue(rate, periods, payment, future float64, timing PaymentTiming) (float64, error)
+	FutureValue(rate, periods, payment, present float64, timing PaymentTiming) (float64, error)
+	Payment(rate, periods, present, future float64, timing PaymentTiming) (float64, error)
+	// NPV discounts each cash flow by its index, so the first falls today and is not discounted
+	NPV(rate float64, cashFlows []float64) (float64, error)
+	// IRR returns the rate at which the NPV of the cash flows is zero
+	IRR(cashFlows []float64) (float64, error)
+	// Amortize schedules repaying principal in equal end-of-period payments; the last payment absorbs rounding
+	Amortize(principal, rate float64, periods int) ([]AmortizationRow, error)
+}
+
+type financeService struct {
+	math MathService
+}
+
+// NewFinanceService returns a FinanceService backed by math
+func NewFinanceService(math MathService) FinanceService {
+	return &financeService{math: math}
+}
+
+// annuity returns the compound growth (1+rate)^periods and the factor a payment is multiplied by to give its
+// future value, (1+rate·due)·((1+rate)^periods-1)/rate, which tends to periods as rate tends to zero
+func (s *financeService) annuity(rate, periods float64, timing PaymentTiming) (growth, factor float64, err error) {
+	if rate <= -1 {
+		return 0, 0, ErrOutOfDomain
+	}
+	if rate == 0 {
+		return 1, periods, nil
+	}
+	if growth, err = s.math.Pow(1+rate, periods); err != nil {
+		return 0, 0, err
+	}
+	factor = (growth - 1) / rate
+	if timing == StartOfPeriod {
+		factor *= 1 + rate
+	}
+	return growth, factor, nil
+}
+
+func (s *financeService) PresentValue(rate, periods, payment, future float64, timing PaymentTiming) (float64, error) {
+	growth, factor, err := s.annuity(rate, periods, timing)
+	if err != nil {
+		return 0, err
+	}
+	return checkResult(-(future + payment*factor) / growth)
+}
+
+func (s *financeService) FutureValue(rate, periods, payment, present float64, timing PaymentTiming) (float64, error) {
+	growth, factor, err := s.annuity(rate, periods, timing)
+	if err != nil {
+		return 0, err
+	}
+	return checkResult(-(present*growth + payment*factor))
+}
+
+func (s *financeService) Payment(rate, periods, present, future float64, timing PaymentTiming) (float64, error) {
+	growth, factor, err := s.annuity(rate, periods, timing)
+	if err != nil {
+		return 0, err
+	}
+	if factor == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return checkResult(-(present*growth + future) / factor)
+}
+
+func (s *financeService) NPV(rate float64, cashFlows []float64) (float64, error) {
+	if err := checkDataset(cashFlows); err != nil {
+		return 0, err
+	}
+	if rate <= -1 {
+		return 0, ErrOutOfDomain
+	}
+
+	discounted := make([]float64, len(cashFlows))
+	for i, flow := range cashFlows {
+		discount, err := s.math.Pow(1+rate, float64(i))
+		if err != nil {
+			return 0, err
+		}
+		discounted[i] = flow / discount
+	}
+	sum, err := s.math.Sum(discounted)
+	if err != nil {
+		return 0, err
+	}
+	return checkResult(sum)
+}
+
+const (
+	irrGuess          = 0.1
+	irrMaxIterations  = 100
+	irrRateTolerance  = 1e-12
+	irrValueTolerance = 1e-9
+	// irrMinRate and irrMaxRate bound the search for a bracketing rate: -99% and a 100,000,000% return per period
+	irrMinRate = -0.99
+	irrMaxRate = 1e6
+)
+
+// IRR runs Newton's method from a 10% guess and falls back to bisection when Newton leaves the domain or stalls.
+// Either way the answer is only returned once the NPV at it is zero to within a tolerance scaled to the cash flows.
+func (s *financeService) IRR(cashFlows []float64) (float64, error) {
+	if err := checkDataset(cashFlows); err != nil {
+		return 0, err
+	}
+
+	var hasOutflow, hasInflow bool
+	var scale float64
+	for _, flow := range cashFlows {
+		hasOutflow = hasOutflow || flow < 0
+		hasInflow = hasInflow || flow > 0
+		scale = math.Max(scale, math.Abs(flow))
+	}
+	if !hasOutflow || !hasInflow {
+		return 0, ErrNoSignChange
+	}
+
+	converged := func(rate float64) bool {
+		value, _ := npvAndSlope(rate, cashFlows)
+		return math.Abs(value) <= irrValueTolerance*scale
+	}
+
+	if rate, ok := irrNewton(cashFlows); ok && converged(rate) {
+		return rate, nil
+	}
+	if rate, ok := irrBisect(cashFlows); ok && converged(rate) {
+		// Bisection stops within irrRateTolerance of the root, which would leave a rate of zero as -3e-13
+		if math.Abs(rate) <= irrRateTolerance {
+			rate = 0
+		}
+		return rate, nil
+	}
+	return 0, ErrNoConvergence
+}
+
+// npvAndSlope returns the NPV of the cash flows at rate and its derivative with respect to rate
+func npvAndSlope(rate float64, cashFlows []float64) (value, slope float64) {
+	discount := 1.0
+	for i, flow := range cashFlows {
+		value += flow / discount
+		slope -= float64(i) * flow / (discount * (1 + rate))
+		discount *= 1 + rate
+	}
+	return value, slope
+}
+
+func irrNewton(cashFlows []float64) (float64, bool) {
+	rate := irrGuess
+	for range irrMaxIterations {
+		value, slope := npvAndSlope(rate, cashFlows)
+		if slope == 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+			return 0, false
+		}
+		next := rate - value/slope
+		if next <= -1 || math.IsNaN(next) {
+			return 0, false
+		}
+		if math.Abs(next-rate) <= irrRateTolerance*(1+math.Abs(rate)) {
+			return next, true
+		}
+		rate = next
+	}
+	return 0, false
+}
+
+// irrBisect looks for a sign change of the NPV between irrMinRate and irrMaxRate, then halves it
+func irrBisect(cashFlows []float64) (float64, bool) {
+	low, high := irrMinRate, 1.0
+	lowValue, _ := npvAndSlope(low, cashFlows)
+	// Over many periods the discount factor at rates near -100% underflows and the NPV is not finite
+	for math.IsNaN(lowValue) || math.IsInf(lowValue, 0) {
+		if low /= 2; low > -irrRateTolerance {
+			return 0, false
+		}
+		lowValue, _ = npvAndSlope(low, cashFlows)
+	}
+	highValue, _ := npvAndSlope(high, cashFlows)
+	for math.Signbit(lowValue) == math.Signbit(highValue) {
+		if high >= irrMaxRate {
+			return 0, false
+		}
+		low, lowValue = high, highValue
+		high *= 10
+		highValue, _ = npvAndSlope(high, cashFlows)
+	}
+
+	for range irrMaxIterations * 2 {
+		mid := low + (high-low)/2
+		if high-low <= irrRateTolerance*(1+math.Abs(mid)) {
+			return mid, true
+		}
+		midValue, _ := npvAndSlope(mid, cashFlows)
+		if math.Signbit(midValue) == math.Signbit(lowValue) {
+			low, lowValue = mid, midValue
+		} else {
+			high = mid
+		}
+	}
+	return low + (high-low)/2, true
+}
+
+func (s *financeService) Amortize(principal, rate float64, periods int) ([]AmortizationRow, error) {
+	if periods < 1 {
+		return nil, ErrOutOfDomain
+	}
+	payment, err := s.Payment(rate, float64(periods), -principal, 0, EndOfPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]AmortizationRow, periods)
+	balance := principal
+	for i := range rows {
+		interest := balance * rate
+		repaid := payment - interest
+		if i == periods-1 {
+			repaid = balance
+		}
+		balance -= repaid
+		rows[i] = AmortizationRow{
+			Period:    i + 1,
+			Payment:   interest + repaid,
+			Interest:  interest,
+			Principal: repaid,
+			Balance:   balance,
+		}
+	}
+	return rows, nil
+}
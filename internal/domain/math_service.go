@@ -1,13 +1,41 @@
 package domain
 
+import "log/slog"
+
 type MathService interface {
-	Add(a, b float64) float64
-	Subtract(a, b float64) float64
-	Multiply(a, b float64) float64
+	Add(a, b float64) (float64, error)
+	Subtract(a, b float64) (float64, error)
+	Multiply(a, b float64) (float64, error)
+	Divide(a, b float64) (float64, error)
+	Modulo(a, b float64, mode DivisionMode) (float64, error)
+	IntDivide(a, b float64, mode DivisionMode) (float64, error)
+	Pow(x, y float64) (float64, error)
+	Sqrt(x float64) (float64, error)
+	NthRoot(x, n float64) (float64, error)
+	Exp(x float64) (float64, error)
+	Log(x float64) (float64, error)
+	Log10(x float64) (float64, error)
+	Log2(x float64) (float64, error)
+	Sin(x float64, unit AngleUnit) (float64, error)
+	Cos(x float64, unit AngleUnit) (float64, error)
+	Tan(x float64, unit AngleUnit) (float64, error)
+	Asin(x float64, unit AngleUnit) (float64, error)
+	Acos(x float64, unit AngleUnit) (float64, error)
+	Atan(x float64, unit AngleUnit) (float64, error)
+	Sum(values []float64) (float64, error)
+	Product(values []float64) (float64, error)
+	Evaluate(expr string, vars map[string]float64) (float64, error)
 }
 
-type mathService struct{}
+type mathService struct {
+	logger *slog.Logger
+}
 
-func NewMathService() MathService {
-	return &mathService{}
+// NewMathService returns the float64 MathService. Operations trace their inputs at debug level on logger,
+// which may be nil to disable logging.
+func NewMathService(logger *slog.Logger) MathService {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	return &mathService{logger: logger}
 }
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// DivisionMode chooses how integer division and modulo round when the operands have different signs
+type DivisionMode string
+
+const (
+	// Truncated rounds the quotient toward zero, so the remainder takes the sign of the dividend: -7 mod 3 = -1
+	Truncated DivisionMode = "truncated"
+	// Euclidean keeps the remainder between zero and |divisor|: -7 mod 3 = 2
+	Euclidean DivisionMode = "euclidean"
+)
+
+// DivisionModes lists every mode; the first is the default
+var DivisionModes = []DivisionMode{Truncated, Euclidean}
+
+// Modulo returns the remainder of a divided by b under mode
+func (m *mathService) Modulo(a, b float64, mode DivisionMode) (float64, error) {
+	m.logger.Debug("modulo", "a", a, "b", b, "mode", mode)
+
+	_, r, err := divMod(a, b, mode)
+	return r, err
+}
+
+// IntDivide returns the integer quotient of a divided by b under mode.
+// Together with Modulo in the same mode it satisfies a = b*IntDivide(a, b) + Modulo(a, b).
+func (m *mathService) IntDivide(a, b float64, mode DivisionMode) (float64, error) {
+	m.logger.Debug("int divide", "a", a, "b", b, "mode", mode)
+
+	q, _, err := divMod(a, b, mode)
+	return q, err
+}
+
+func divMod(a, b float64, mode DivisionMode) (q, r float64, err error) {
+	if b == 0 {
+		return 0, 0, ErrDivisionByZero
+	}
+	if r, err = checkResult(math.Mod(a, b)); err != nil {
+		return 0, 0, err
+	}
+	// Derived from r rather than as Trunc(a/b), which rounding can leave one off: 1/0.1 truncates to 10 while
+	// Mod(1, 0.1) is 0.0999…, because 0.1 is slightly more than a tenth
+	if q, err = checkResult(math.Round((a - r) / b)); err != nil {
+		return 0, 0, err
+	}
+
+	switch mode {
+	case Truncated:
+	case Euclidean:
+		if r < 0 {
+			r += math.Abs(b)
+			if b > 0 {
+				q--
+			} else {
+				q++
+			}
+		}
+	default:
+		return 0, 0, fmt.Errorf("unknown division mode %q", mode)
+	}
+	return q, r, nil
+}
@@ -1,9 +1,7 @@
 package domain
 
-import "log"
+func (m *mathService) Multiply(a, b float64) (float64, error) {
+	m.logger.Debug("multiply", "a", a, "b", b)
 
-func (m *mathService) Multiply(a, b float64) float64 {
-	log.Printf("Debug: b value is %f", b)
-
-	return a * a
+	return checkResult(a * b)
 }
@@ -0,0 +1,10 @@
+package domain
+
+// Product multiplies all values together
+func (m *mathService) Product(values []float64) (float64, error) {
+	product := 1.0
+	for _, v := range values {
+		product *= v
+	}
+	return checkResult(product)
+}
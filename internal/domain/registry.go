@@ -0,0 +1,290 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// Operation describes an arithmetic operation that can be served generically.
+// Arity is the number of parameters; Apply and Exact receive arguments in Params order
+// and report domain failures such as ErrDivisionByZero as errors.
+type Operation struct {
+	Name   string
+	Params []string
+	Apply  func(args []float64) (float64, error)
+	// Exact is optional; operations without it are only available in float precision
+	Exact func(args []*big.Rat) (*big.Rat, error)
+	// Fold and ExactFold are optional and let the operation accept any number of operands
+	Fold      func(args []float64) (float64, error)
+	ExactFold func(args []*big.Rat) (*big.Rat, error)
+	// Complex and ComplexFold are optional complex128 implementations
+	Complex     func(args []complex128) (complex128, error)
+	ComplexFold func(args []complex128) (complex128, error)
+	// Modes are optional named variants selected by the caller; Apply and Exact are used when none is chosen
+	Modes map[string]Mode
+	// RealValued marks operations whose complex results always have a zero imaginary part, such as abs
+	RealValued bool
+	// Group optionally names the family an operation belongs to, such as "finance"; grouped operations are served
+	// under /{group}/{name} rather than /{name}
+	Group string
+}
+
+// Mode is a named variant of an operation, such as the Euclidean flavour of modulo
+type Mode struct {
+	Apply   func(args []float64) (float64, error)
+	Exact   func(args []*big.Rat) (*big.Rat, error)
+	Complex func(args []complex128) (complex128, error)
+}
+
+// WithMode returns the operation computed in the named mode. The result takes no operand lists.
+func (o Operation) WithMode(name string) (Operation, bool) {
+	mode, ok := o.Modes[name]
+	if !ok {
+		return Operation{}, false
+	}
+	o.Apply, o.Exact, o.Complex = mode.Apply, mode.Exact, mode.Complex
+	o.Fold, o.ExactFold, o.ComplexFold = nil, nil, nil
+	return o, true
+}
+
+// ModeNames returns the names of the operation's modes in sorted order
+func (o Operation) ModeNames() []string {
+	names := make([]string, 0, len(o.Modes))
+	for name := range o.Modes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Arity returns the number of operands the operation takes
+func (o Operation) Arity() int {
+	return len(o.Params)
+}
+
+// Registry holds operations by name, preserving registration order
+type Registry struct {
+	operations map[string]Operation
+	order      []string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{operations: make(map[string]Operation)}
+}
+
+// NewStandardRegistry returns a registry containing every built-in operation
+func NewStandardRegistry(math MathService, exact ExactMathService, complexMath ComplexMathService, finance FinanceService) *Registry {
+	r := NewRegistry()
+
+	r.MustRegister(Operation{
+		Name:        "add",
+		Params:      []string{"a", "b"},
+		Apply:       func(args []float64) (float64, error) { return math.Add(args[0], args[1]) },
+		Exact:       func(args []*big.Rat) (*big.Rat, error) { return exact.Add(args[0], args[1]) },
+		Complex:     func(args []complex128) (complex128, error) { return complexMath.Add(args[0], args[1]) },
+		Fold:        math.Sum,
+		ExactFold:   exact.Sum,
+		ComplexFold: complexMath.Sum,
+	})
+	r.MustRegister(Operation{
+		Name:    "sub",
+		Params:  []string{"a", "b"},
+		Apply:   func(args []float64) (float64, error) { return math.Subtract(args[0], args[1]) },
+		Exact:   func(args []*big.Rat) (*big.Rat, error) { return exact.Subtract(args[0], args[1]) },
+		Complex: func(args []complex128) (complex128, error) { return complexMath.Subtract(args[0], args[1]) },
+	})
+	r.MustRegister(Operation{
+		Name:        "mul",
+		Params:      []string{"a", "b"},
+		Apply:       func(args []float64) (float64, error) { return math.Multiply(args[0], args[1]) },
+		Exact:       func(args []*big.Rat) (*big.Rat, error) { return exact.Multiply(args[0], args[1]) },
+		Complex:     func(args []complex128) (complex128, error) { return complexMath.Multiply(args[0], args[1]) },
+		Fold:        math.Product,
+		ExactFold:   exact.Product,
+		ComplexFold: complexMath.Product,
+	})
+	r.MustRegister(Operation{
+		Name:    "div",
+		Params:  []string{"a", "b"},
+		Apply:   func(args []float64) (float64, error) { return math.Divide(args[0], args[1]) },
+		Exact:   func(args []*big.Rat) (*big.Rat, error) { return exact.Divide(args[0], args[1]) },
+		Complex: func(args []complex128) (complex128, error) { return complexMath.Divide(args[0], args[1]) },
+	})
+	r.MustRegister(withDivisionModes(Operation{Name: "mod", Params: []string{"a", "b"}}, math.Modulo, exact.Modulo))
+	r.MustRegister(withDivisionModes(Operation{Name: "idiv", Params: []string{"a", "b"}}, math.IntDivide, exact.IntDivide))
+
+	// Scientific functions have no exact implementation
+	r.MustRegister(Operation{
+		Name:    "pow",
+		Params:  []string{"x", "y"},
+		Apply:   func(args []float64) (float64, error) { return math.Pow(args[0], args[1]) },
+		Complex: func(args []complex128) (complex128, error) { return complexMath.Pow(args[0], args[1]) },
+	})
+	r.MustRegister(Operation{
+		Name:    "root",
+		Params:  []string{"x", "n"},
+		Apply:   func(args []float64) (float64, error) { return math.NthRoot(args[0], args[1]) },
+		Complex: func(args []complex128) (complex128, error) { return complexMath.NthRoot(args[0], args[1]) },
+	})
+	r.MustRegister(unary("sqrt", math.Sqrt, complexMath.Sqrt))
+	r.MustRegister(unary("exp", math.Exp, complexMath.Exp))
+	r.MustRegister(unary("log", math.Log, complexMath.Log))
+	r.MustRegister(unary("log10", math.Log10, complexMath.Log10))
+	r.MustRegister(unary("log2", math.Log2, complexMath.Log2))
+	r.MustRegister(withAngleUnits("sin", math.Sin, complexMath.Sin))
+	r.MustRegister(withAngleUnits("cos", math.Cos, complexMath.Cos))
+	r.MustRegister(withAngleUnits("tan", math.Tan, complexMath.Tan))
+	r.MustRegister(withAngleUnits("asin", math.Asin, complexMath.Asin))
+	r.MustRegister(withAngleUnits("acos", math.Acos, complexMath.Acos))
+	r.MustRegister(withAngleUnits("atan", math.Atan, complexMath.Atan))
+
+	// Complex parts; a real x is the complex number x+0i
+	r.MustRegister(realValued("abs", complexMath.Abs))
+	r.MustRegister(realValued("arg", complexMath.Arg))
+	r.MustRegister(unary("conj", func(x float64) (float64, error) { return x, nil }, complexMath.Conj))
+
+	// Time value of money, float only
+	r.MustRegister(withPaymentTimings("pv", []string{"rate", "n", "pmt", "fv"}, finance.PresentValue))
+	r.MustRegister(withPaymentTimings("fv", []string{"rate", "n", "pmt", "pv"}, finance.FutureValue))
+	r.MustRegister(withPaymentTimings("pmt", []string{"rate", "n", "pv", "fv"}, finance.Payment))
+
+	return r
+}
+
+// unary wraps a one-operand function and its complex counterpart as an operation taking 'x'
+func unary(name string, f func(x float64) (float64, error), cf func(z complex128) (complex128, error)) Operation {
+	return Operation{
+		Name:    name,
+		Params:  []string{"x"},
+		Apply:   func(args []float64) (float64, error) { return f(args[0]) },
+		Complex: func(args []complex128) (complex128, error) { return cf(args[0]) },
+	}
+}
+
+// realValued wraps a real-valued function of a complex number, such as its modulus, as an operation taking 'x'
+func realValued(name string, f func(z complex128) (float64, error)) Operation {
+	op := unary(name,
+		func(x float64) (float64, error) { return f(complex(x, 0)) },
+		func(z complex128) (complex128, error) {
+			v, err := f(z)
+			return complex(v, 0), err
+		})
+	op.RealValued = true
+	return op
+}
+
+// withAngleUnits wraps a trigonometric function as an operation taking 'x', with one mode per AngleUnit
+// defaulting to the first. Complex arguments are only meaningful in radians.
+func withAngleUnits(name string, f func(x float64, unit AngleUnit) (float64, error), cf func(z complex128) (complex128, error)) Operation {
+	op := Operation{Name: name, Params: []string{"x"}, Modes: make(map[string]Mode, len(AngleUnits))}
+	for _, unit := range AngleUnits {
+		mode := Mode{
+			Apply: func(args []float64) (float64, error) { return f(args[0], unit) },
+		}
+		if unit == Radians {
+			mode.Complex = func(args []complex128) (complex128, error) { return cf(args[0]) }
+		}
+		op.Modes[string(unit)] = mode
+	}
+	op.Apply = op.Modes[string(AngleUnits[0])].Apply
+	op.Complex = op.Modes[string(AngleUnits[0])].Complex
+	return op
+}
+
+// withPaymentTimings wraps a four-operand annuity formula as a finance operation with one mode per PaymentTiming,
+// defaulting to the first
+func withPaymentTimings(name string, params []string, f func(a, b, c, d float64, timing PaymentTiming) (float64, error)) Operation {
+	op := Operation{Name: name, Params: params, Modes: make(map[string]Mode, len(PaymentTimings)), Group: "finance"}
+	for _, timing := range PaymentTimings {
+		op.Modes[string(timing)] = Mode{
+			Apply: func(args []float64) (float64, error) { return f(args[0], args[1], args[2], args[3], timing) },
+		}
+	}
+	op.Apply = op.Modes[string(PaymentTimings[0])].Apply
+	return op
+}
+
+// withDivisionModes gives op one mode per DivisionMode, defaulting to the first
+func withDivisionModes(op Operation,
+	apply func(a, b float64, mode DivisionMode) (float64, error),
+	exact func(a, b *big.Rat, mode DivisionMode) (*big.Rat, error),
+) Operation {
+	op.Modes = make(map[string]Mode, len(DivisionModes))
+	for _, mode := range DivisionModes {
+		op.Modes[string(mode)] = Mode{
+			Apply: func(args []float64) (float64, error) { return apply(args[0], args[1], mode) },
+			Exact: func(args []*big.Rat) (*big.Rat, error) { return exact(args[0], args[1], mode) },
+		}
+	}
+	op.Apply = op.Modes[string(DivisionModes[0])].Apply
+	op.Exact = op.Modes[string(DivisionModes[0])].Exact
+	return op
+}
+
+// Register adds op to the registry, rejecting incomplete or duplicate operations
+func (r *Registry) Register(op Operation) error {
+	if op.Name == "" {
+		return fmt.Errorf("operation name is required")
+	}
+	if op.Arity() == 0 {
+		return fmt.Errorf("operation %q must declare at least one parameter", op.Name)
+	}
+	if op.Apply == nil {
+		return fmt.Errorf("operation %q has no Apply function", op.Name)
+	}
+	if _, exists := r.operations[op.Name]; exists {
+		return fmt.Errorf("operation %q is already registered", op.Name)
+	}
+
+	r.operations[op.Name] = op
+	r.order = append(r.order, op.Name)
+	return nil
+}
+
+// MustRegister is like Register but panics on error; intended for built-in operations
+func (r *Registry) MustRegister(op Operation) {
+	if err := r.Register(op); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the operation registered under name
+func (r *Registry) Lookup(name string) (Operation, bool) {
+	op, ok := r.operations[name]
+	return op, ok
+}
+
+// Operations returns every registered operation in registration order
+func (r *Registry) Operations() []Operation {
+	ops := make([]Operation, 0, len(r.order))
+	for _, name := range r.order {
+		ops = append(ops, r.operations[name])
+	}
+	return ops
+}
+
+// Names returns the name of every registered operation in registration order
+func (r *Registry) Names() []string {
+	return append([]string(nil), r.order...)
+}
+
+// Select returns a registry holding only the named operations, in the order given.
+// An empty list selects every operation.
+func (r *Registry) Select(names []string) (*Registry, error) {
+	if len(names) == 0 {
+		return r, nil
+	}
+
+	selected := NewRegistry()
+	for _, name := range names {
+		op, ok := r.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+		if err := selected.Register(op); err != nil {
+			return nil, err
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "math"
+
+// Pow raises x to the power y
+func (m *mathService) Pow(x, y float64) (float64, error) {
+	m.logger.Debug("pow", "x", x, "y", y)
+
+	switch {
+	case x == 0 && y < 0:
+		return 0, ErrDivisionByZero
+	case x < 0 && y != math.Trunc(y):
+		return 0, ErrOutOfDomain
+	}
+	return checkResult(math.Pow(x, y))
+}
+
+// Sqrt returns the square root of x
+func (m *mathService) Sqrt(x float64) (float64, error) {
+	m.logger.Debug("sqrt", "x", x)
+
+	if x < 0 {
+		return 0, ErrNegativeRoot
+	}
+	return checkResult(math.Sqrt(x))
+}
+
+// NthRoot returns the nth root of x. Negative x has a real root only for odd integer n.
+func (m *mathService) NthRoot(x, n float64) (float64, error) {
+	m.logger.Debug("nth root", "x", x, "n", n)
+
+	if n == 0 {
+		return 0, ErrOutOfDomain
+	}
+	if x < 0 {
+		if n != math.Trunc(n) || math.Mod(n, 2) == 0 {
+			return 0, ErrNegativeRoot
+		}
+		root, err := m.NthRoot(-x, n)
+		return -root, err
+	}
+	if x == 0 && n < 0 {
+		return 0, ErrDivisionByZero
+	}
+	return checkResult(math.Pow(x, 1/n))
+}
+
+// Exp returns e raised to the power x
+func (m *mathService) Exp(x float64) (float64, error) {
+	m.logger.Debug("exp", "x", x)
+
+	return checkResult(math.Exp(x))
+}
+
+// Log returns the natural logarithm of x
+func (m *mathService) Log(x float64) (float64, error) {
+	m.logger.Debug("log", "x", x)
+
+	return logarithm(math.Log, x)
+}
+
+// Log10 returns the base 10 logarithm of x
+func (m *mathService) Log10(x float64) (float64, error) {
+	m.logger.Debug("log10", "x", x)
+
+	return logarithm(math.Log10, x)
+}
+
+// Log2 returns the base 2 logarithm of x
+func (m *mathService) Log2(x float64) (float64, error) {
+	m.logger.Debug("log2", "x", x)
+
+	return logarithm(math.Log2, x)
+}
+
+func logarithm(log func(float64) float64, x float64) (float64, error) {
+	if x <= 0 {
+		return 0, ErrLogNonPositive
+	}
+	return checkResult(log(x))
+}
@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Dataset errors report inputs too small for a statistic
+var (
+	ErrEmptyDataset = errors.New("dataset is empty")
+	ErrTooFewValues = errors.New("sample statistics need at least two values")
+)
+
+// VarianceKind chooses between the population variance (divide by n) and the sample variance (divide by n-1)
+type VarianceKind string
+
+const (
+	Sample     VarianceKind = "sample"
+	Population VarianceKind = "population"
+)
+
+// Bin is one histogram bucket covering [Lower, Upper); the last bucket also includes its upper bound
+type Bin struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+// StatisticsService computes descriptive statistics over a dataset.
+// Every method rejects an empty dataset with ErrEmptyDataset and non-finite values with a domain error.
+type StatisticsService interface {
+	Mean(values []float64) (float64, error)
+	Median(values []float64) (float64, error)
+	// Mode returns every most frequent value, in ascending order
+	Mode(values []float64) ([]float64, error)
+	Variance(values []float64, kind VarianceKind) (float64, error)
+	StdDev(values []float64, kind VarianceKind) (float64, error)
+	// Percentile interpolates linearly between the closest ranks, p in [0, 100]
+	Percentile(values []float64, p float64) (float64, error)
+	Min(values []float64) (float64, error)
+	Max(values []float64) (float64, error)
+	// Histogram splits the range of values into bins equal-width buckets
+	Histogram(values []float64, bins int) ([]Bin, error)
+}
+
+type statisticsService struct {
+	math MathService
+}
+
+// NewStatisticsService returns a StatisticsService backed by math
+func NewStatisticsService(math MathService) StatisticsService {
+	return &statisticsService{math: math}
+}
+
+// checkDataset rejects datasets no statistic can be computed over
+func checkDataset(values []float64) error {
+	if len(values) == 0 {
+		return ErrEmptyDataset
+	}
+	for _, v := range values {
+		if _, err := checkResult(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *statisticsService) Mean(values []float64) (float64, error) {
+	if err := checkDataset(values); err != nil {
+		return 0, err
+	}
+	sum, err := s.math.Sum(values)
+	if errors.Is(err, ErrOverflow) {
+		// The mean of finite values is finite even when their sum is not, so fall back to a running mean
+		return checkResult(runningMean(values))
+	}
+	if err != nil {
+		return 0, err
+	}
+	return checkResult(sum / float64(len(values)))
+}
+
+// runningMean updates the mean one value at a time, as Welford's algorithm does, dividing each term before
+// subtracting so no intermediate exceeds the largest value
+func runningMean(values []float64) float64 {
+	var mean float64
+	for i, v := range values {
+		n := float64(i + 1)
+		mean += v/n - mean/n
+	}
+	return mean
+}
+
+func (s *statisticsService) Median(values []float64) (float64, error) {
+	return s.Percentile(values, 50)
+}
+
+func (s *statisticsService) Mode(values []float64) ([]float64, error) {
+	if err := checkDataset(values); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[float64]int, len(values))
+	best := 0
+	for _, v := range values {
+		counts[v]++
+		best = max(best, counts[v])
+	}
+
+	var modes []float64
+	for v, n := range counts {
+		if n == best {
+			modes = append(modes, v)
+		}
+	}
+	slices.Sort(modes)
+	return modes, nil
+}
+
+// Variance uses Welford's online algorithm, which avoids the cancellation of the naive sum-of-squares formula
+func (s *statisticsService) Variance(values []float64, kind VarianceKind) (float64, error) {
+	if err := checkDataset(values); err != nil {
+		return 0, err
+	}
+
+	var mean, m2 float64
+	for i, v := range values {
+		delta := v - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (v - mean)
+	}
+
+	switch kind {
+	case Population:
+		return checkResult(m2 / float64(len(values)))
+	case Sample:
+		if len(values) < 2 {
+			return 0, ErrTooFewValues
+		}
+		return checkResult(m2 / float64(len(values)-1))
+	default:
+		return 0, fmt.Errorf("unknown variance kind %q", kind)
+	}
+}
+
+func (s *statisticsService) StdDev(values []float64, kind VarianceKind) (float64, error) {
+	variance, err := s.Variance(values, kind)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(variance), nil
+}
+
+func (s *statisticsService) Percentile(values []float64, p float64) (float64, error) {
+	if err := checkDataset(values); err != nil {
+		return 0, err
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, ErrOutOfDomain
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower == len(sorted)-1 {
+		return sorted[lower], nil
+	}
+	fraction := rank - float64(lower)
+	return checkResult(sorted[lower] + fraction*(sorted[lower+1]-sorted[lower]))
+}
+
+func (s *statisticsService) Min(values []float64) (float64, error) {
+	if err := checkDataset(values); err != nil {
+		return 0, err
+	}
+	return slices.Min(values), nil
+}
+
+func (s *statisticsService) Max(values []float64) (float64, error) {
+	if err := checkDataset(values); err != nil {
+		return 0, err
+	}
+	return slices.Max(values), nil
+}
+
+func (s *statisticsService) Histogram(values []float64, bins int) ([]Bin, error) {
+	if err := checkDataset(values); err != nil {
+		return nil, err
+	}
+	if bins < 1 {
+		return nil, ErrOutOfDomain
+	}
+
+	lo, hi := slices.Min(values), slices.Max(values)
+	if lo == hi {
+		// Equal values leave no range to split, so centre one unit on them (or more, where a unit is below their
+		// precision) to still give the requested bins
+		half := math.Max(0.5, math.Abs(lo)*1e-9)
+		lo, hi = lo-half, hi+half
+	}
+
+	width, err := checkResult((hi - lo) / float64(bins))
+	if err != nil {
+		return nil, err
+	}
+	histogram := make([]Bin, bins)
+	for i := range histogram {
+		histogram[i].Lower = lo + float64(i)*width
+		histogram[i].Upper = lo + float64(i+1)*width
+	}
+	histogram[bins-1].Upper = hi
+
+	for _, v := range values {
+		i := min(int((v-lo)/width), bins-1)
+		histogram[i].Count++
+	}
+	return histogram, nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "math"
+
+// Sum adds values using Kahan–Babuška (Neumaier) compensated summation, so the rounding error
+// stays bounded however many values are added
+func (m *mathService) Sum(values []float64) (float64, error) {
+	var sum, compensation float64
+	for _, v := range values {
+		t := sum + v
+		if math.IsInf(t, 0) {
+			return checkResult(t)
+		}
+		if math.Abs(sum) >= math.Abs(v) {
+			compensation += (sum - t) + v
+		} else {
+			compensation += (v - t) + sum
+		}
+		sum = t
+	}
+	return checkResult(sum + compensation)
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// AngleUnit is the unit trigonometric functions take angles in and inverse functions return them in
+type AngleUnit string
+
+const (
+	Radians AngleUnit = "rad"
+	Degrees AngleUnit = "deg"
+)
+
+// AngleUnits lists every unit; the first is the default
+var AngleUnits = []AngleUnit{Radians, Degrees}
+
+func (m *mathService) Sin(x float64, unit AngleUnit) (float64, error) {
+	m.logger.Debug("sin", "x", x, "unit", unit)
+
+	if unit == Degrees {
+		// Exact at multiples of 90° instead of leaving float residue such as sin(180°) = 1.2e-16
+		switch d := normalizeDegrees(x); d {
+		case 0, 180:
+			return 0, nil
+		case 90:
+			return 1, nil
+		case 270:
+			return -1, nil
+		}
+	}
+	rad, err := toRadians(x, unit)
+	if err != nil {
+		return 0, err
+	}
+	return checkResult(math.Sin(rad))
+}
+
+func (m *mathService) Cos(x float64, unit AngleUnit) (float64, error) {
+	m.logger.Debug("cos", "x", x, "unit", unit)
+
+	if unit == Degrees {
+		switch d := normalizeDegrees(x); d {
+		case 90, 270:
+			return 0, nil
+		case 0:
+			return 1, nil
+		case 180:
+			return -1, nil
+		}
+	}
+	rad, err := toRadians(x, unit)
+	if err != nil {
+		return 0, err
+	}
+	return checkResult(math.Cos(rad))
+}
+
+// Tan returns the tangent of x. In degrees it is undefined at 90° and 270°.
+func (m *mathService) Tan(x float64, unit AngleUnit) (float64, error) {
+	m.logger.Debug("tan", "x", x, "unit", unit)
+
+	if unit == Degrees {
+		switch d := normalizeDegrees(x); d {
+		case 0, 180:
+			return 0, nil
+		case 90, 270:
+			return 0, ErrOutOfDomain
+		}
+	}
+	rad, err := toRadians(x, unit)
+	if err != nil {
+		return 0, err
+	}
+	return checkResult(math.Tan(rad))
+}
+
+// Asin returns the angle whose sine is x, for x in [-1, 1]
+func (m *mathService) Asin(x float64, unit AngleUnit) (float64, error) {
+	m.logger.Debug("asin", "x", x, "unit", unit)
+
+	if x < -1 || x > 1 {
+		return 0, ErrOutOfDomain
+	}
+	return fromRadians(math.Asin(x), unit)
+}
+
+// Acos returns the angle whose cosine is x, for x in [-1, 1]
+func (m *mathService) Acos(x float64, unit AngleUnit) (float64, error) {
+	m.logger.Debug("acos", "x", x, "unit", unit)
+
+	if x < -1 || x > 1 {
+		return 0, ErrOutOfDomain
+	}
+	return fromRadians(math.Acos(x), unit)
+}
+
+func (m *mathService) Atan(x float64, unit AngleUnit) (float64, error) {
+	m.logger.Debug("atan", "x", x, "unit", unit)
+
+	return fromRadians(math.Atan(x), unit)
+}
+
+// normalizeDegrees maps x into [0, 360)
+func normalizeDegrees(x float64) float64 {
+	d := math.Mod(x, 360)
+	if d < 0 {
+		d += 360
+	}
+	return d
+}
+
+func toRadians(x float64, unit AngleUnit) (float64, error) {
+	switch unit {
+	case Radians:
+		return x, nil
+	case Degrees:
+		return normalizeDegrees(x) * math.Pi / 180, nil
+	default:
+		return 0, fmt.Errorf("unknown angle unit %q", unit)
+	}
+}
+
+func fromRadians(rad float64, unit AngleUnit) (float64, error) {
+	switch unit {
+	case Radians:
+		return checkResult(rad)
+	case Degrees:
+		return checkResult(rad * 180 / math.Pi)
+	default:
+		return 0, fmt.Errorf("unknown angle unit %q", unit)
+	}
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/domain"
+)
+
+const (
+	maxBatchBodyBytes = 4 << 20
+	maxBatchItems     = 10000
+)
+
+type batchItem struct {
+	Op   string     `json:"op"`
+	Args []batchArg `json:"args"`
+	Mode string     `json:"mode,omitempty"`
+}
+
+// batchArg is an operand given as a JSON number, or as a string for numbers JSON cannot write, such as "1/3" or
+// "3+4i"
+type batchArg string
+
+func (a *batchArg) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*a = batchArg(s)
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	*a = batchArg(number)
+	return nil
+}
+
+type batchItemResult struct {
+	Op     string            `json:"op"`
+	Status int               `json:"status"`
+	Result any               `json:"result,omitempty"`
+	Error  *apierror.Problem `json:"error,omitempty"`
+}
+
+// Batch returns a handler that runs a JSON array of {op, args} items through the registry.
+// Each item succeeds or fails independently and results are returned in request order.
+func (h *Handlers) Batch(registry *domain.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			h.writeError(w, r, apierror.MethodNotAllowed(r.Method))
+			return
+		}
+
+		precision, err := h.requestPrecision(r)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		var items []batchItem
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&items); err != nil {
+			h.writeError(w, r, apierror.InvalidBody(fmt.Sprintf("body must be a JSON array of {op, args} items: %v", err)))
+			return
+		}
+		if len(items) > maxBatchItems {
+			h.writeError(w, r, apierror.InvalidBody(fmt.Sprintf("a batch may contain at most %d items", maxBatchItems)))
+			return
+		}
+
+		// Without an explicit precision each item uses the server default, or float where its operation has none
+		explicit := r.URL.Query().Get("precision") != ""
+
+		results := make([]batchItemResult, len(items))
+		for i, item := range items {
+			result, err := h.runBatchItem(registry, precision, explicit, item)
+			if err != nil {
+				apiErr := toAPIError(err)
+				problem := apiErr.Problem(fmt.Sprintf("%s#%d", r.URL.Path, i))
+				results[i] = batchItemResult{Op: item.Op, Status: apiErr.Status, Error: &problem}
+				continue
+			}
+			results[i] = batchItemResult{Op: item.Op, Status: http.StatusOK, Result: result}
+		}
+
+		encoded, err := json.Marshal(results)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(encoded)
+	}
+}
+
+// runBatchItem computes a single item, returning a float64, a decimal or fraction string in exact and fraction
+// precision, or a complexValue in complex precision. explicit says whether the request named precision.
+func (h *Handlers) runBatchItem(registry *domain.Registry, precision Precision, explicit bool, item batchItem) (any, error) {
+	op, ok := registry.Lookup(item.Op)
+	if !ok {
+		return nil, apierror.UnknownOperation(item.Op)
+	}
+	op, err := selectMode(op, item.Mode)
+	if err != nil {
+		return nil, err
+	}
+	if !explicit {
+		precision = h.defaultPrecisionFor(op)
+		// As in a query, complex operands select complex precision and operands written as fractions select fraction
+		// precision
+		if slices.ContainsFunc(item.Args, func(arg batchArg) bool { return isComplex(string(arg)) }) {
+			precision = PrecisionComplex
+		} else if op.Exact != nil && slices.ContainsFunc(item.Args, func(arg batchArg) bool { return isFraction(string(arg)) }) {
+			precision = PrecisionFraction
+		}
+	}
+	// Operations with a fold accept any number of arguments; everything else must match its arity
+	folding := len(item.Args) != op.Arity() && op.Fold != nil
+	if len(item.Args) == 0 || (len(item.Args) != op.Arity() && !folding) {
+		return nil, apierror.InvalidArguments(fmt.Sprintf("operation '%s' takes %d arguments, got %d", op.Name, op.Arity(), len(item.Args)))
+	}
+
+	if precision == PrecisionComplex {
+		if op.Complex == nil || (folding && op.ComplexFold == nil) {
+			return nil, apierror.InvalidParameter("precision", fmt.Sprintf("operation '%s' does not support %s precision", op.Name, precision))
+		}
+		args := make([]complex128, len(item.Args))
+		for i, arg := range item.Args {
+			value, ok := parseComplexNumber(string(arg))
+			if !ok {
+				return nil, apierror.InvalidNumber(batchParamName(op, folding, i))
+			}
+			args[i] = value
+		}
+		apply := op.Complex
+		if folding {
+			apply = op.ComplexFold
+		}
+		result, err := apply(args)
+		if err != nil {
+			return nil, err
+		}
+		if op.RealValued {
+			return real(result), nil
+		}
+		return newComplexValue(result), nil
+	}
+
+	if precision != PrecisionFloat {
+		if op.Exact == nil || (folding && op.ExactFold == nil) {
+			return nil, apierror.InvalidParameter("precision", fmt.Sprintf("operation '%s' does not support %s precision", op.Name, precision))
+		}
+		args := make([]*big.Rat, len(item.Args))
+		for i, arg := range item.Args {
+			value, ok := parseRat(string(arg))
+			if !ok {
+				return nil, apierror.InvalidNumber(batchParamName(op, folding, i))
+			}
+			args[i] = value
+		}
+		apply := op.Exact
+		if folding {
+			apply = op.ExactFold
+		}
+		result, err := apply(args)
+		if err != nil {
+			return nil, err
+		}
+		if precision == PrecisionFraction {
+			return result.RatString(), nil
+		}
+		return FormatExact(result, 0), nil
+	}
+
+	args := make([]float64, len(item.Args))
+	for i, arg := range item.Args {
+		value, ok := parseNumber(string(arg))
+		if !ok {
+			return nil, apierror.InvalidNumber(batchParamName(op, folding, i))
+		}
+		args[i] = value
+	}
+	if folding {
+		return op.Fold(args)
+	}
+	return op.Apply(args)
+}
+
+// batchParamName names the i-th argument of an item for error reporting
+func batchParamName(op domain.Operation, folding bool, i int) string {
+	if folding {
+		return fmt.Sprintf("args[%d]", i)
+	}
+	return op.Params[i]
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/domain"
+)
+
+// parseComplexNumber parses a complex number written as a+bi, bi or a real number, including a fraction.
+// A bare i stands for 1i, and a '+' decoded from an unescaped query string as a space is read back as '+'.
+func parseComplexNumber(s string) (complex128, bool) {
+	if x, ok := parseNumber(s); ok {
+		return complex(x, 0), true
+	}
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "+")
+	if body, ok := strings.CutSuffix(s, "i"); ok && (body == "" || strings.HasSuffix(body, "+") || strings.HasSuffix(body, "-")) {
+		s = body + "1i"
+	}
+	z, err := strconv.ParseComplex(s, 128)
+	return z, err == nil
+}
+
+// isComplex reports whether s is a complex number rather than a real one
+func isComplex(s string) bool {
+	if _, ok := parseNumber(s); ok {
+		return false
+	}
+	_, ok := parseComplexNumber(s)
+	return ok
+}
+
+// ParseComplexOperands extracts and validates one query parameter per name as a complex number
+func ParseComplexOperands(r *http.Request, names []string) ([]complex128, error) {
+	values, err := requiredParams(r, names)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]complex128, len(names))
+	for i, name := range names {
+		var ok bool
+		if args[i], ok = parseComplexNumber(values[i]); !ok {
+			return nil, apierror.InvalidNumber(name)
+		}
+	}
+
+	return args, nil
+}
+
+// ParseComplexOperandList is ParseOperandList for complex numbers
+func ParseComplexOperandList(r *http.Request) (values []complex128, listed bool, err error) {
+	raw, name, listed, err := operandList(r)
+	if !listed || err != nil {
+		return nil, listed, err
+	}
+
+	values = make([]complex128, len(raw))
+	for i, s := range raw {
+		var ok bool
+		if values[i], ok = parseComplexNumber(s); !ok {
+			return nil, true, apierror.InvalidNumber(name)
+		}
+	}
+	return values, true, nil
+}
+
+// complexValue is the JSON form of a complex number
+type complexValue struct {
+	Re float64 `json:"re"`
+	Im float64 `json:"im"`
+}
+
+func newComplexValue(z complex128) complexValue {
+	return complexValue{Re: real(z), Im: imag(z)}
+}
+
+// formatComplex renders z as a+bi with the configured number of decimal places, e.g. 3.00-4.00i
+func (h *Handlers) formatComplex(z complex128) string {
+	re, im := h.formatFloat(real(z)), h.formatFloat(math.Abs(imag(z)))
+	if isZero(re) {
+		re = strings.TrimPrefix(re, "-")
+	}
+	sign := "+"
+	if imag(z) < 0 && !isZero(im) {
+		sign = "-"
+	}
+	return re + sign + im + "i"
+}
+
+// isZero reports whether a formatted number rounds to zero, such as -0.00
+func isZero(formatted string) bool {
+	return strings.Trim(formatted, "-0.") == ""
+}
+
+// complexOperation computes op over complex numbers. JSON responses carry operands and result as {re, im} objects,
+// except that real-valued operations such as abs answer with a plain number.
+func (h *Handlers) complexOperation(r *http.Request, op domain.Operation) (operationResult, error) {
+	if op.Complex == nil {
+		return operationResult{}, apierror.InvalidParameter("precision", fmt.Sprintf("operation '%s' does not support %s precision", op.Name, PrecisionComplex))
+	}
+
+	var values []complex128
+	var listed bool
+	var err error
+	if readsOperandList(op) {
+		if values, listed, err = ParseComplexOperandList(r); err != nil {
+			return operationResult{}, err
+		}
+	}
+
+	params := op.Params
+	apply := op.Complex
+	if listed {
+		if op.ComplexFold == nil {
+			return operationResult{}, operandListUnsupported(op)
+		}
+		params, apply = []string{valuesParam}, op.ComplexFold
+	} else if values, err = ParseComplexOperands(r, op.Params); err != nil {
+		return operationResult{}, err
+	}
+
+	result, err := apply(values)
+	if err != nil {
+		return operationResult{}, err
+	}
+
+	formatted := make([]complexValue, len(values))
+	for i, v := range values {
+		formatted[i] = newComplexValue(v)
+	}
+	args := []any{formatted}
+	if !listed {
+		args = make([]any, len(formatted))
+		for i, v := range formatted {
+			args[i] = v
+		}
+	}
+
+	if op.RealValued {
+		return operationResult{
+			operation: op.Name,
+			params:    params,
+			args:      args,
+			result:    real(result),
+			text:      h.formatFloat(real(result)),
+		}, nil
+	}
+	return operationResult{
+		operation: op.Name,
+		params:    params,
+		args:      args,
+		result:    newComplexValue(result),
+		text:      h.formatComplex(result),
+	}, nil
+}
+
+// angleUnit reads the 'mode' query parameter naming the unit of an angle, defaulting to radians
+func angleUnit(r *http.Request) (domain.AngleUnit, error) {
+	mode := r.URL.Query().Get(modeParam)
+	if mode == "" {
+		return domain.AngleUnits[0], nil
+	}
+	for _, unit := range domain.AngleUnits {
+		if mode == string(unit) {
+			return unit, nil
+		}
+	}
+	return "", apierror.InvalidParameter(modeParam, fmt.Sprintf("mode must be '%s' or '%s'", domain.Radians, domain.Degrees))
+}
+
+type polarResult struct {
+	Operation string       `json:"operation"`
+	Z         complexValue `json:"z"`
+	R         float64      `json:"r"`
+	Theta     float64      `json:"theta"`
+}
+
+// Polar returns a handler converting the complex number 'z' to its modulus and argument, written "r,theta" as text.
+// mode=deg gives the argument in degrees.
+func (h *Handlers) Polar(complexMath domain.ComplexMathService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			h.writeError(w, r, apierror.MethodNotAllowed(r.Method))
+			return
+		}
+
+		format, err := NegotiateFormat(r)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		unit, err := angleUnit(r)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		args, err := ParseComplexOperands(r, []string{"z"})
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		modulus, theta, err := complexMath.Polar(args[0], unit)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		result := polarResult{Operation: "polar", Z: newComplexValue(args[0]), R: modulus, Theta: theta}
+		h.writeFormatted(w, r, format, h.formatFloat(modulus)+","+h.formatFloat(theta), result)
+	}
+}
+
+// Rect returns a handler converting the modulus 'r' and argument 'theta' to a complex number.
+// mode=deg takes the argument in degrees.
+func (h *Handlers) Rect(complexMath domain.ComplexMathService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			h.writeError(w, r, apierror.MethodNotAllowed(r.Method))
+			return
+		}
+
+		format, err := NegotiateFormat(r)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		unit, err := angleUnit(r)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		params := []string{"r", "theta"}
+		args, err := ParseOperands(r, params)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		z, err := complexMath.Rect(args[0], args[1], unit)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		result := operationResult{
+			operation: "rect",
+			params:    params,
+			args:      []any{args[0], args[1]},
+			result:    newComplexValue(z),
+			text:      h.formatComplex(z),
+		}
+		h.writeFormatted(w, r, format, result.text, result)
+	}
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"tech-test/internal/apierror"
+)
+
+const maxDatasetBodyBytes = 4 << 20
+
+// ParseDataset reads the values a statistic is computed over. GET requests list them in the query like an operand
+// list; POST requests send a JSON array of numbers or, as text/csv, a CSV document. A CSV whose first row is not
+// numeric is treated as having a header. The 'column' parameter picks one column by header name or 0-based index;
+// without it every field is a value.
+func ParseDataset(w http.ResponseWriter, r *http.Request) ([]float64, error) {
+	switch r.Method {
+	case http.MethodGet:
+		values, listed, err := ParseOperandList(r)
+		if err != nil {
+			return nil, err
+		}
+		if !listed {
+			return nil, apierror.MissingParameter(valuesParam, "the dataset is required as repeated 'x' parameters or a comma-separated 'values'")
+		}
+		return values, nil
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		return nil, apierror.MethodNotAllowed(r.Method)
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxDatasetBodyBytes)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "", "application/json":
+		var values []float64
+		if err := json.NewDecoder(body).Decode(&values); err != nil {
+			return nil, apierror.InvalidBody(fmt.Sprintf("body must be a JSON array of numbers: %v", err))
+		}
+		return values, nil
+	case "text/csv", "text/plain":
+		return parseCSVDataset(body, r.URL.Query().Get("column"))
+	default:
+		return nil, apierror.InvalidBody(fmt.Sprintf("unsupported content type %q: send application/json or text/csv", mediaType))
+	}
+}
+
+func parseCSVDataset(body io.Reader, column string) ([]float64, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		var maxBytes *http.MaxBytesError
+		if errors.As(err, &maxBytes) {
+			return nil, apierror.InvalidBody(fmt.Sprintf("body exceeds %d bytes", maxBytes.Limit))
+		}
+		return nil, apierror.InvalidBody(fmt.Sprintf("body is not valid CSV: %v", err))
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	var header []string
+	if !numericRecord(records[0]) {
+		header, records = records[0], records[1:]
+	}
+
+	index := -1
+	if column != "" {
+		index = columnIndex(header, column)
+		if index < 0 {
+			return nil, apierror.InvalidParameter("column", fmt.Sprintf("column '%s' is not in the CSV header", column))
+		}
+	}
+
+	var values []float64
+	for i, record := range records {
+		fields := record
+		if index >= 0 {
+			if index >= len(record) {
+				return nil, apierror.InvalidBody(fmt.Sprintf("CSV row %d has no column %d", i+1, index))
+			}
+			fields = record[index : index+1]
+		}
+		for _, field := range fields {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, apierror.InvalidBody(fmt.Sprintf("CSV value %q is not a number", field))
+			}
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+func numericRecord(record []string) bool {
+	for _, field := range record {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(field), 64); err != nil && strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// columnIndex resolves a column by header name, falling back to a 0-based index
+func columnIndex(header []string, column string) int {
+	for i, name := range header {
+		if strings.TrimSpace(name) == column {
+			return i
+		}
+	}
+	if i, err := strconv.Atoi(column); err == nil && i >= 0 {
+		return i
+	}
+	return -1
+}
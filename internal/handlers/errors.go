@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/domain"
+)
+
+// writeError logs internal errors and renders err with WriteError
+func (h *Handlers) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if toAPIError(err).Code == apierror.CodeInternal {
+		h.logger.ErrorContext(r.Context(), "request failed", "path", r.URL.Path, "error", err)
+	}
+	WriteError(w, r, err)
+}
+
+// WriteError renders err as application/problem+json when the client negotiated JSON, or as plain text otherwise.
+// It is shared by every endpoint, including those outside this package, so all errors follow one model.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	apiErr := toAPIError(err)
+
+	if apiErr.Status == http.StatusMethodNotAllowed && w.Header().Get("Allow") == "" {
+		w.Header().Set("Allow", http.MethodGet)
+	}
+
+	format, negotiateErr := NegotiateFormat(r)
+	if negotiateErr != nil {
+		format = FormatText
+	}
+
+	if format == FormatJSON {
+		encoded, marshalErr := json.Marshal(apiErr.Problem(r.URL.Path))
+		if marshalErr == nil {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(apiErr.Status)
+			w.Write(encoded)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(apiErr.Status)
+	fmt.Fprint(w, apiErr.Detail)
+}
+
+// toAPIError classifies err: domain errors become 422 responses, and any other error that is not an
+// *apierror.Error is reported as an internal error without leaking its message
+func toAPIError(err error) *apierror.Error {
+	var apiErr *apierror.Error
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr
+	case domain.IsDomainError(err):
+		return apierror.Domain(err)
+	default:
+		return apierror.Internal()
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/domain"
+)
+
+func (h *Handlers) Eval(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, r, apierror.MethodNotAllowed(r.Method))
+		return
+	}
+
+	format, err := NegotiateFormat(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	// Expressions are evaluated in float64 only, whatever the server default; only an explicit request for another
+	// precision is an error
+	if value := r.URL.Query().Get("precision"); value != "" {
+		precision, err := ParsePrecision(value)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		if precision != PrecisionFloat {
+			h.writeError(w, r, apierror.InvalidParameter("precision", fmt.Sprintf("operation 'eval' does not support %s precision", precision)))
+			return
+		}
+	}
+
+	expr, vars, err := ParseExprParams(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	result, err := h.mathService.Evaluate(expr, vars)
+	if err != nil {
+		var exprErr *domain.ExprError
+		if errors.As(err, &exprErr) {
+			err = apierror.InvalidExpression(exprErr.Column, exprErr.Error())
+		}
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeFormatted(w, r, format, h.formatFloat(result), evalResult{
+		Operation:  "eval",
+		Expression: expr,
+		Variables:  vars,
+		Result:     result,
+	})
+}
+
+type evalResult struct {
+	Operation  string             `json:"operation"`
+	Expression string             `json:"expression"`
+	Variables  map[string]float64 `json:"variables"`
+	Result     float64            `json:"result"`
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"tech-test/internal/domain"
+)
+
+func TestEvalPrecision(t *testing.T) {
+	testCases := []struct {
+		name       string
+		precision  string
+		expectCode int
+		expected   string
+	}{
+		{name: "server default", expectCode: http.StatusOK, expected: "0.33"},
+		{name: "explicit float", precision: "float", expectCode: http.StatusOK, expected: "0.33"},
+		{name: "explicit exact", precision: "exact", expectCode: http.StatusBadRequest, expected: "operation 'eval' does not support exact precision"},
+		{name: "explicit complex", precision: "complex", expectCode: http.StatusBadRequest, expected: "operation 'eval' does not support complex precision"},
+		{name: "unknown precision", precision: "double", expectCode: http.StatusBadRequest, expected: "precision must be"},
+	}
+
+	// A non-float server default must not stop expressions being evaluated
+	h := NewHandlers(domain.NewMathService(slog.New(slog.DiscardHandler)), WithDefaultPrecision(PrecisionExact))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query := url.Values{"expr": {"1/3"}}
+			if tc.precision != "" {
+				query.Set("precision", tc.precision)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/eval?"+query.Encode(), nil)
+			rec := httptest.NewRecorder()
+
+			h.Eval(rec, req)
+
+			if rec.Code != tc.expectCode {
+				t.Errorf("Expected status %d, got %d", tc.expectCode, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tc.expected) {
+				t.Errorf("Expected body containing '%s', got '%s'", tc.expected, rec.Body.String())
+			}
+		})
+	}
+}
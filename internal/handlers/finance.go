@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/domain"
+)
+
+// maxAmortizationPeriods bounds the size of a schedule; 1200 is a century of monthly payments
+const maxAmortizationPeriods = 1200
+
+// FinanceCalculations returns the name of every calculation Finance serves
+func FinanceCalculations() []string {
+	return []string{"npv", "irr", "amortization"}
+}
+
+// Finance returns a handler for /finance/{name}: the net present value and internal rate of return of a series of
+// cash flows, read like a dataset (see ParseDataset), and loan amortization schedules. The annuity formulas served
+// alongside them are registry operations. It panics if name is not one of FinanceCalculations.
+func (h *Handlers) Finance(finance domain.FinanceService, name string) http.HandlerFunc {
+	switch name {
+	case "npv", "irr":
+		return func(w http.ResponseWriter, r *http.Request) {
+			h.cashFlowCalculation(w, r, finance, name)
+		}
+	case "amortization":
+		return func(w http.ResponseWriter, r *http.Request) {
+			h.amortization(w, r, finance)
+		}
+	}
+	panic(fmt.Sprintf("unknown finance calculation %q", name))
+}
+
+type cashFlowResult struct {
+	Calculation string   `json:"calculation"`
+	Rate        *float64 `json:"rate,omitempty"`
+	Count       int      `json:"count"`
+	Result      float64  `json:"result"`
+}
+
+func (h *Handlers) cashFlowCalculation(w http.ResponseWriter, r *http.Request, finance domain.FinanceService, name string) {
+	format, err := NegotiateFormat(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	var rate []float64
+	if name == "npv" {
+		if rate, err = ParseOperands(r, []string{"rate"}); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	}
+
+	cashFlows, err := ParseDataset(w, r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	result := cashFlowResult{Calculation: name, Count: len(cashFlows)}
+	if name == "npv" {
+		result.Rate = &rate[0]
+		result.Result, err = finance.NPV(rate[0], cashFlows)
+	} else {
+		result.Result, err = finance.IRR(cashFlows)
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeFormatted(w, r, format, h.formatFloat(result.Result), result)
+}
+
+type amortizationResult struct {
+	Calculation   string                   `json:"calculation"`
+	Principal     float64                  `json:"principal"`
+	Rate          float64                  `json:"rate"`
+	Periods       int                      `json:"n"`
+	Payment       float64                  `json:"payment"`
+	TotalInterest float64                  `json:"total_interest"`
+	Schedule      []domain.AmortizationRow `json:"schedule"`
+}
+
+func (h *Handlers) amortization(w http.ResponseWriter, r *http.Request, finance domain.FinanceService) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, r, apierror.MethodNotAllowed(r.Method))
+		return
+	}
+
+	format, err := negotiateTableFormat(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	args, err := ParseOperands(r, []string{"principal", "rate", "n"})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	periods := int(args[2])
+	if float64(periods) != args[2] || periods < 1 || periods > maxAmortizationPeriods {
+		h.writeError(w, r, apierror.InvalidParameter("n", fmt.Sprintf("'n' must be a whole number of periods between 1 and %d", maxAmortizationPeriods)))
+		return
+	}
+
+	schedule, err := finance.Amortize(args[0], args[1], periods)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	result := amortizationResult{
+		Calculation: "amortization",
+		Principal:   args[0],
+		Rate:        args[1],
+		Periods:     periods,
+		Payment:     schedule[0].Payment,
+		Schedule:    schedule,
+	}
+	for _, row := range schedule {
+		result.TotalInterest += row.Interest
+	}
+
+	if format == FormatCSV {
+		h.writeScheduleCSV(w, schedule)
+		return
+	}
+	h.writeFormatted(w, r, format, h.formatSchedule(schedule), result)
+}
+
+var scheduleColumns = []string{"period", "payment", "interest", "principal", "balance"}
+
+func (h *Handlers) scheduleRecord(row domain.AmortizationRow) []string {
+	return []string{
+		strconv.Itoa(row.Period),
+		h.formatFloat(row.Payment),
+		h.formatFloat(row.Interest),
+		h.formatFloat(row.Principal),
+		h.formatFloat(row.Balance),
+	}
+}
+
+// formatSchedule renders the schedule as a plain-text table with right-aligned columns
+func (h *Handlers) formatSchedule(schedule []domain.AmortizationRow) string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, strings.Join(scheduleColumns, "\t")+"\t")
+	for _, row := range schedule {
+		fmt.Fprintln(tw, strings.Join(h.scheduleRecord(row), "\t")+"\t")
+	}
+	tw.Flush()
+	return b.String()
+}
+
+func (h *Handlers) writeScheduleCSV(w http.ResponseWriter, schedule []domain.AmortizationRow) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.WriteHeader(http.StatusOK)
+	cw := csv.NewWriter(w)
+	cw.Write(scheduleColumns)
+	for _, row := range schedule {
+		cw.Write(h.scheduleRecord(row))
+	}
+	cw.Flush()
+}
+
+// negotiateTableFormat is NegotiateFormat for responses that can also be sent as CSV, chosen by format=csv or an
+// Accept header weighting text/csv above the other formats
+func negotiateTableFormat(r *http.Request) (Format, error) {
+	switch r.URL.Query().Get("format") {
+	case string(FormatCSV):
+		return FormatCSV, nil
+	case "":
+		return preferredFormat(r.Header.Get("Accept"), true), nil
+	}
+	return NegotiateFormat(r)
+}
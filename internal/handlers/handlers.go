@@ -1,28 +1,78 @@
 package handlers
 
 import (
-	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
+
+	"tech-test/internal/apierror"
 	"tech-test/internal/domain"
 )
 
 type Handlers struct {
-	mathService domain.MathService
+	mathService      domain.MathService
+	logger           *slog.Logger
+	defaultPrecision Precision
+	decimalPlaces    int
+}
+
+// Option configures optional behaviour of Handlers
+type Option func(*Handlers)
+
+// WithDefaultPrecision sets the precision used when a request does not specify one
+func WithDefaultPrecision(precision Precision) Option {
+	return func(h *Handlers) {
+		h.defaultPrecision = precision
+	}
 }
 
-func NewHandlers(mathService domain.MathService) *Handlers {
-	return &Handlers{
-		mathService: mathService,
+// WithLogger sets the logger used to report unexpected failures
+func WithLogger(logger *slog.Logger) Option {
+	return func(h *Handlers) {
+		h.logger = logger
 	}
 }
 
+// WithDecimalPlaces sets how many decimal places plain-text results are rounded to
+func WithDecimalPlaces(places int) Option {
+	return func(h *Handlers) {
+		h.decimalPlaces = places
+	}
+}
+
+func NewHandlers(mathService domain.MathService, opts ...Option) *Handlers {
+	h := &Handlers{
+		mathService:      mathService,
+		logger:           slog.New(slog.DiscardHandler),
+		defaultPrecision: PrecisionFloat,
+		decimalPlaces:    2,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
 func (h *Handlers) Ping(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		h.writeError(w, r, apierror.MethodNotAllowed(r.Method))
+		return
+	}
+
+	format, err := NegotiateFormat(r)
+	if err != nil {
+		h.writeError(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(http.StatusOK)
-	fmt.Fprint(w, "pong")
+	h.writeFormatted(w, r, format, "pong", pingResult{Message: "pong"})
+}
+
+// formatFloat renders a plain-text result with the configured number of decimal places
+func (h *Handlers) formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', h.decimalPlaces, 64)
+}
+
+type pingResult struct {
+	Message string `json:"message"`
 }
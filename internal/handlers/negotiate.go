@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"tech-test/internal/apierror"
+)
+
+// Format is the representation used for a response body
+type Format string
+
+const (
+	// FormatText renders results as plain text rounded to two decimal places
+	FormatText Format = "text"
+	// FormatJSON renders results as JSON objects carrying the unrounded result
+	FormatJSON Format = "json"
+	// FormatCSV renders tables as CSV; only endpoints returning tables offer it
+	FormatCSV Format = "csv"
+)
+
+// NegotiateFormat picks the response format from the 'format' query parameter, falling back to the Accept header.
+// Plain text is used unless JSON is explicitly preferred.
+func NegotiateFormat(r *http.Request) (Format, error) {
+	switch value := r.URL.Query().Get("format"); value {
+	case "":
+	case string(FormatText), string(FormatJSON):
+		return Format(value), nil
+	default:
+		return "", apierror.InvalidParameter("format", fmt.Sprintf("format must be '%s' or '%s'", FormatText, FormatJSON))
+	}
+
+	return preferredFormat(r.Header.Get("Accept"), false), nil
+}
+
+// preferredFormat returns the format of the media type the Accept header weights highest, or plain text when it
+// accepts none of them. CSV is only a candidate when csv is set.
+func preferredFormat(accept string, csv bool) Format {
+	best, bestQ := FormatText, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if qStr, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(qStr, 64); err != nil {
+				continue
+			}
+		}
+
+		var format Format
+		switch mediaType {
+		case "application/json", "application/problem+json":
+			format = FormatJSON
+		case "text/plain", "text/*":
+			format = FormatText
+		case "text/csv":
+			if !csv {
+				continue
+			}
+			format = FormatCSV
+		default:
+			continue
+		}
+
+		if q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+
+	return best
+}
+
+// writeFormatted writes text or the JSON encoding of body, depending on format
+func (h *Handlers) writeFormatted(w http.ResponseWriter, r *http.Request, format Format, text string, body any) {
+	if format == FormatJSON {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(encoded)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, text)
+}
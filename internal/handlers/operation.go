@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/domain"
+)
+
+// Operation returns a handler serving op, reading one query parameter per declared operand.
+// Operations with a fold also accept an operand list through repeated 'x' or a comma-separated 'values',
+// and operations with modes take an optional 'mode'.
+func (h *Handlers) Operation(op domain.Operation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			h.writeError(w, r, apierror.MethodNotAllowed(r.Method))
+			return
+		}
+
+		format, err := NegotiateFormat(r)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		selected, err := selectMode(op, r.URL.Query().Get(modeParam))
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		precision, err := h.operationPrecision(r, selected)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		var result operationResult
+		switch precision {
+		case PrecisionFloat:
+			result, err = h.floatOperation(r, selected)
+		case PrecisionComplex:
+			result, err = h.complexOperation(r, selected)
+		default:
+			result, err = h.exactOperation(r, selected, precision)
+		}
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		h.writeFormatted(w, r, format, result.text, result)
+	}
+}
+
+func (h *Handlers) floatOperation(r *http.Request, op domain.Operation) (operationResult, error) {
+	var values []float64
+	var listed bool
+	var err error
+	if readsOperandList(op) {
+		if values, listed, err = ParseOperandList(r); err != nil {
+			return operationResult{}, err
+		}
+	}
+
+	if listed {
+		if op.Fold == nil {
+			return operationResult{}, operandListUnsupported(op)
+		}
+		result, err := op.Fold(values)
+		if err != nil {
+			return operationResult{}, err
+		}
+		return operationResult{
+			operation: op.Name,
+			params:    []string{valuesParam},
+			args:      []any{values},
+			result:    result,
+			text:      h.formatFloat(result),
+		}, nil
+	}
+
+	args, err := ParseOperands(r, op.Params)
+	if err != nil {
+		return operationResult{}, err
+	}
+	result, err := op.Apply(args)
+	if err != nil {
+		return operationResult{}, err
+	}
+
+	anyArgs := make([]any, len(args))
+	for i, arg := range args {
+		anyArgs[i] = arg
+	}
+	return operationResult{
+		operation: op.Name,
+		params:    op.Params,
+		args:      anyArgs,
+		result:    result,
+		text:      h.formatFloat(result),
+	}, nil
+}
+
+// modeParam names the variant of an operation to compute, e.g. mode=euclidean for mod
+const modeParam = "mode"
+
+// selectMode returns op computed in the named mode, or op unchanged when no mode is named
+func selectMode(op domain.Operation, mode string) (domain.Operation, error) {
+	if mode == "" {
+		return op, nil
+	}
+	if selected, ok := op.WithMode(mode); ok {
+		return selected, nil
+	}
+	if len(op.Modes) == 0 {
+		return domain.Operation{}, apierror.InvalidParameter(modeParam, fmt.Sprintf("operation '%s' has no modes", op.Name))
+	}
+	return domain.Operation{}, apierror.InvalidParameter(modeParam,
+		fmt.Sprintf("operation '%s' supports modes: %s", op.Name, strings.Join(op.ModeNames(), ", ")))
+}
+
+// readsOperandList reports whether requests for op are checked for an operand list.
+// Operations that declare their own 'x' operand, such as sqrt, read it as a single value instead.
+func readsOperandList(op domain.Operation) bool {
+	return !slices.Contains(op.Params, repeatedParam)
+}
+
+func operandListUnsupported(op domain.Operation) error {
+	return apierror.InvalidParameter(valuesParam, fmt.Sprintf("operation '%s' does not accept an operand list", op.Name))
+}
+
+// operationResult is the JSON body for an operation: its name, each operand keyed by parameter name, then the result
+type operationResult struct {
+	operation string
+	params    []string
+	args      []any
+	result    any
+	// text is the plain-text rendering of result
+	text string
+}
+
+func (o operationResult) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString(`{"operation":`)
+	if err := writeJSONValue(&buf, o.operation); err != nil {
+		return nil, err
+	}
+
+	for i, name := range o.params {
+		buf.WriteByte(',')
+		if err := writeJSONValue(&buf, name); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := writeJSONValue(&buf, o.args[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteString(`,"result":`)
+	if err := writeJSONValue(&buf, o.result); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+func writeJSONValue(buf *bytes.Buffer, v any) error {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+	return nil
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/domain"
+)
+
+// Precision selects which MathService implementation serves a request
+type Precision string
+
+const (
+	// PrecisionFloat computes with float64 and formats to two decimal places
+	PrecisionFloat Precision = "float"
+	// PrecisionExact computes with big.Rat and formats the exact result
+	PrecisionExact Precision = "exact"
+	// PrecisionFraction computes with big.Rat and formats the result as a fraction in lowest terms
+	PrecisionFraction Precision = "fraction"
+	// PrecisionComplex computes with complex128 and formats the result as a+bi
+	PrecisionComplex Precision = "complex"
+)
+
+// ParsePrecision validates a precision name
+func ParsePrecision(s string) (Precision, error) {
+	switch Precision(s) {
+	case PrecisionFloat, PrecisionExact, PrecisionFraction, PrecisionComplex:
+		return Precision(s), nil
+	default:
+		return "", apierror.InvalidParameter("precision", fmt.Sprintf("precision must be '%s', '%s', '%s' or '%s'",
+			PrecisionFloat, PrecisionExact, PrecisionFraction, PrecisionComplex))
+	}
+}
+
+// requestPrecision returns the 'precision' query parameter, falling back to the server default
+func (h *Handlers) requestPrecision(r *http.Request) (Precision, error) {
+	value := r.URL.Query().Get("precision")
+	if value == "" {
+		return h.defaultPrecision, nil
+	}
+	return ParsePrecision(value)
+}
+
+// operationPrecision is requestPrecision for op when the request names no precision: complex operands select
+// complex precision, operands written as fractions select fraction precision for operations with an exact
+// implementation, and a server default op cannot be computed in falls back to float
+func (h *Handlers) operationPrecision(r *http.Request, op domain.Operation) (Precision, error) {
+	query := r.URL.Query()
+	if query.Get("precision") == "" {
+		fraction := false
+		for name, values := range query {
+			if reservedParams[name] {
+				continue
+			}
+			for _, value := range values {
+				// A comma-separated 'values' list holds several operands
+				operands := strings.Split(value, ",")
+				if slices.ContainsFunc(operands, isComplex) {
+					return PrecisionComplex, nil
+				}
+				fraction = fraction || slices.ContainsFunc(operands, isFraction)
+			}
+		}
+		if fraction && op.Exact != nil {
+			return PrecisionFraction, nil
+		}
+		return h.defaultPrecisionFor(op), nil
+	}
+	return h.requestPrecision(r)
+}
+
+// defaultPrecisionFor returns the server default precision, or float when op has no implementation in it
+func (h *Handlers) defaultPrecisionFor(op domain.Operation) Precision {
+	switch h.defaultPrecision {
+	case PrecisionExact, PrecisionFraction:
+		if op.Exact == nil {
+			return PrecisionFloat
+		}
+	case PrecisionComplex:
+		if op.Complex == nil {
+			return PrecisionFloat
+		}
+	}
+	return h.defaultPrecision
+}
+
+// Fraction results are rendered in lowest terms unless render=decimal asks for a rounded decimal
+const (
+	renderParam    = "render"
+	renderFraction = "fraction"
+	renderDecimal  = "decimal"
+)
+
+// exactFormatters returns how operands and results are written in JSON and how the result is written as text
+// for an exact or fraction precision request
+func (h *Handlers) exactFormatters(r *http.Request, precision Precision) (value, text func(*big.Rat) string, err error) {
+	if precision != PrecisionFraction {
+		return func(v *big.Rat) string { return FormatExact(v, 0) },
+			func(v *big.Rat) string { return FormatExact(v, h.decimalPlaces) }, nil
+	}
+
+	value = (*big.Rat).RatString
+	switch r.URL.Query().Get(renderParam) {
+	case "", renderFraction:
+		return value, value, nil
+	case renderDecimal:
+		return value, func(v *big.Rat) string { return v.FloatString(h.decimalPlaces) }, nil
+	default:
+		return nil, nil, apierror.InvalidParameter(renderParam, fmt.Sprintf("render must be '%s' or '%s'", renderFraction, renderDecimal))
+	}
+}
+
+// exactOperation computes op using exact arithmetic.
+// JSON responses carry operands and result as decimal or fraction strings so no precision is lost in transit.
+func (h *Handlers) exactOperation(r *http.Request, op domain.Operation, precision Precision) (operationResult, error) {
+	if op.Exact == nil {
+		return operationResult{}, apierror.InvalidParameter("precision", fmt.Sprintf("operation '%s' does not support %s precision", op.Name, precision))
+	}
+	formatValue, formatText, err := h.exactFormatters(r, precision)
+	if err != nil {
+		return operationResult{}, err
+	}
+
+	var values []*big.Rat
+	var listed bool
+	if readsOperandList(op) {
+		if values, listed, err = ParseExactOperandList(r); err != nil {
+			return operationResult{}, err
+		}
+	}
+
+	var params []string
+	var args []any
+	var result *big.Rat
+	if listed {
+		if op.ExactFold == nil {
+			return operationResult{}, operandListUnsupported(op)
+		}
+		if result, err = op.ExactFold(values); err != nil {
+			return operationResult{}, err
+		}
+		formatted := make([]string, len(values))
+		for i, v := range values {
+			formatted[i] = formatValue(v)
+		}
+		params, args = []string{valuesParam}, []any{formatted}
+	} else {
+		if values, err = ParseExactOperands(r, op.Params); err != nil {
+			return operationResult{}, err
+		}
+		if result, err = op.Exact(values); err != nil {
+			return operationResult{}, err
+		}
+		args = make([]any, len(values))
+		for i, v := range values {
+			args[i] = formatValue(v)
+		}
+		params = op.Params
+	}
+
+	return operationResult{
+		operation: op.Name,
+		params:    params,
+		args:      args,
+		result:    formatValue(result),
+		text:      formatText(result),
+	}, nil
+}
+
+// FormatExact renders r as a decimal with at least minDecimals places and as many more as are needed to be exact.
+// Values without a terminating decimal expansion are rendered as a fraction.
+func FormatExact(r *big.Rat, minDecimals int) string {
+	digits, ok := terminatingDigits(r.Denom())
+	if !ok {
+		return r.RatString()
+	}
+	return r.FloatString(max(digits, minDecimals))
+}
+
+// terminatingDigits reports how many decimal places are needed to represent 1/denom exactly,
+// or false when denom has prime factors other than 2 and 5
+func terminatingDigits(denom *big.Int) (int, bool) {
+	d := new(big.Int).Set(denom)
+	two, five := big.NewInt(2), big.NewInt(5)
+	mod := new(big.Int)
+
+	twos, fives := 0, 0
+	for d.Cmp(big.NewInt(1)) != 0 {
+		switch {
+		case mod.Mod(d, two).Sign() == 0:
+			d.Quo(d, two)
+			twos++
+		case mod.Mod(d, five).Sign() == 0:
+			d.Quo(d, five)
+			fives++
+		default:
+			return 0, false
+		}
+	}
+	return max(twos, fives), true
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tech-test/internal/domain"
+)
+
+// newTestMux serves every standard operation and /batch with the given server default precision
+func newTestMux(precision Precision) *http.ServeMux {
+	math := domain.NewMathService(slog.New(slog.DiscardHandler))
+	registry := domain.NewStandardRegistry(math, domain.NewExactMathService(), domain.NewComplexMathService(math), domain.NewFinanceService(math))
+	h := NewHandlers(math, WithDefaultPrecision(precision))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/batch", h.Batch(registry))
+	for _, op := range registry.Operations() {
+		path := "/" + op.Name
+		if op.Group != "" {
+			path = "/" + op.Group + path
+		}
+		mux.HandleFunc(path, h.Operation(op))
+	}
+	return mux
+}
+
+func TestDefaultPrecisionFallsBackToFloat(t *testing.T) {
+	testCases := []struct {
+		name       string
+		precision  Precision
+		method     string
+		url        string
+		body       string
+		expectCode int
+		expected   string
+	}{
+		{"exact default on an exact operation", PrecisionExact, http.MethodGet, "/add?a=0.1&b=0.2&format=json", "", http.StatusOK, `"result":"0.3"`},
+		{"exact default on a float-only operation", PrecisionExact, http.MethodGet, "/sqrt?x=2", "", http.StatusOK, "1.41"},
+		{"fraction default on a float-only operation", PrecisionFraction, http.MethodGet, "/sin?x=90&mode=deg", "", http.StatusOK, "1.00"},
+		{"fraction default on a finance operation", PrecisionFraction, http.MethodGet, "/finance/pmt?rate=0&n=10&pv=-100&fv=0", "", http.StatusOK, "10.00"},
+		{"complex default on a complex operation", PrecisionComplex, http.MethodGet, "/sqrt?x=-4", "", http.StatusOK, "0.00+2.00i"},
+		{"complex default on a degree mode", PrecisionComplex, http.MethodGet, "/sin?x=90&mode=deg", "", http.StatusOK, "1.00"},
+		{"explicit exact on a float-only operation", PrecisionExact, http.MethodGet, "/sqrt?x=2&precision=exact", "", http.StatusBadRequest,
+			"operation 'sqrt' does not support exact precision"},
+		{"exact default in a batch", PrecisionExact, http.MethodPost, "/batch",
+			`[{"op":"sqrt","args":[4]},{"op":"add","args":[0.1,0.2]}]`, http.StatusOK,
+			`[{"op":"sqrt","status":200,"result":2},{"op":"add","status":200,"result":"0.3"}]`},
+		{"explicit exact in a batch", PrecisionExact, http.MethodPost, "/batch?precision=exact",
+			`[{"op":"sqrt","args":[4]}]`, http.StatusOK, "operation 'sqrt' does not support exact precision"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+
+			newTestMux(tc.precision).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectCode {
+				t.Errorf("Expected status %d, got %d", tc.expectCode, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tc.expected) {
+				t.Errorf("Expected body containing '%s', got '%s'", tc.expected, rec.Body.String())
+			}
+		})
+	}
+}
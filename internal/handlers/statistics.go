@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/domain"
+)
+
+// defaultHistogramBins is used when a histogram request gives no 'bins'
+const defaultHistogramBins = 10
+
+// StatisticNames returns the name of every statistic Statistic serves, in sorted order
+func StatisticNames() []string {
+	return slices.Sorted(maps.Keys(statistics))
+}
+
+// Statistic returns a handler for /stats/{name} computing the named statistic over the request's dataset.
+// See ParseDataset for the accepted inputs. It panics if name is not one of StatisticNames.
+func (h *Handlers) Statistic(stats domain.StatisticsService, name string) http.HandlerFunc {
+	compute, ok := statistics[name]
+	if !ok {
+		panic(fmt.Sprintf("unknown statistic %q", name))
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		format, err := NegotiateFormat(r)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		values, err := ParseDataset(w, r)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		result := statisticResult{Statistic: name, Count: len(values)}
+		if err := compute(stats, r, values, &result); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+
+		h.writeFormatted(w, r, format, h.formatStatistic(result.Result), result)
+	}
+}
+
+type statisticResult struct {
+	Statistic string              `json:"statistic"`
+	Count     int                 `json:"count"`
+	Kind      domain.VarianceKind `json:"kind,omitempty"`
+	P         *float64            `json:"p,omitempty"`
+	Result    any                 `json:"result"`
+}
+
+// statistics maps each statistic name to the function computing it into result
+var statistics = map[string]func(stats domain.StatisticsService, r *http.Request, values []float64, result *statisticResult) error{
+	"mean": func(stats domain.StatisticsService, _ *http.Request, values []float64, result *statisticResult) (err error) {
+		result.Result, err = stats.Mean(values)
+		return err
+	},
+	"median": func(stats domain.StatisticsService, _ *http.Request, values []float64, result *statisticResult) (err error) {
+		result.Result, err = stats.Median(values)
+		return err
+	},
+	"mode": func(stats domain.StatisticsService, _ *http.Request, values []float64, result *statisticResult) (err error) {
+		result.Result, err = stats.Mode(values)
+		return err
+	},
+	"variance": func(stats domain.StatisticsService, r *http.Request, values []float64, result *statisticResult) (err error) {
+		if result.Kind, err = varianceKind(r); err != nil {
+			return err
+		}
+		result.Result, err = stats.Variance(values, result.Kind)
+		return err
+	},
+	"stddev": func(stats domain.StatisticsService, r *http.Request, values []float64, result *statisticResult) (err error) {
+		if result.Kind, err = varianceKind(r); err != nil {
+			return err
+		}
+		result.Result, err = stats.StdDev(values, result.Kind)
+		return err
+	},
+	"percentile": func(stats domain.StatisticsService, r *http.Request, values []float64, result *statisticResult) error {
+		args, err := ParseOperands(r, []string{"p"})
+		if err != nil {
+			return err
+		}
+		if p := args[0]; p < 0 || p > 100 {
+			return apierror.InvalidParameter("p", "'p' must be between 0 and 100")
+		}
+		result.P = &args[0]
+		result.Result, err = stats.Percentile(values, args[0])
+		return err
+	},
+	"min": func(stats domain.StatisticsService, _ *http.Request, values []float64, result *statisticResult) (err error) {
+		result.Result, err = stats.Min(values)
+		return err
+	},
+	"max": func(stats domain.StatisticsService, _ *http.Request, values []float64, result *statisticResult) (err error) {
+		result.Result, err = stats.Max(values)
+		return err
+	},
+	"histogram": func(stats domain.StatisticsService, r *http.Request, values []float64, result *statisticResult) error {
+		bins := defaultHistogramBins
+		if raw := r.URL.Query().Get("bins"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 || n > 1000 {
+				return apierror.InvalidParameter("bins", "'bins' must be a whole number between 1 and 1000")
+			}
+			bins = n
+		}
+		var err error
+		result.Result, err = stats.Histogram(values, bins)
+		return err
+	},
+}
+
+// varianceKind reads the 'kind' query parameter, defaulting to the sample variance
+func varianceKind(r *http.Request) (domain.VarianceKind, error) {
+	switch kind := domain.VarianceKind(r.URL.Query().Get("kind")); kind {
+	case "":
+		return domain.Sample, nil
+	case domain.Sample, domain.Population:
+		return kind, nil
+	default:
+		return "", apierror.InvalidParameter("kind", fmt.Sprintf("kind must be '%s' or '%s'", domain.Sample, domain.Population))
+	}
+}
+
+// formatStatistic renders a statistic as text: a number, a comma-separated list of modes or one line per histogram bin
+func (h *Handlers) formatStatistic(result any) string {
+	switch v := result.(type) {
+	case float64:
+		return h.formatFloat(v)
+	case []float64:
+		formatted := make([]string, len(v))
+		for i, x := range v {
+			formatted[i] = h.formatFloat(x)
+		}
+		return strings.Join(formatted, ",")
+	case []domain.Bin:
+		var b strings.Builder
+		for i, bin := range v {
+			closing := ")"
+			if i == len(v)-1 {
+				closing = "]"
+			}
+			fmt.Fprintf(&b, "[%s, %s%s: %d\n", h.formatFloat(bin.Lower), h.formatFloat(bin.Upper), closing, bin.Count)
+		}
+		return b.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
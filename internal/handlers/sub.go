@@ -1,27 +0,0 @@
-package handlers
-
-import (
-	"fmt"
-	"net/http"
-)
-
-func (h *Handlers) Sub(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	a, b, err := ParseQueryParams(r)
-	if err != nil {
-		w.Header().Set("Content-Type", "text/plain")
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprint(w, err.Error())
-		return
-	}
-
-	result := h.mathService.Subtract(*a, *b)
-
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(http.StatusOK)
-	fmt.Fprintf(w, "%.2f", result)
-}
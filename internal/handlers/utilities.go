@@ -1,29 +1,232 @@
 package handlers
 
 import (
-	"errors"
+	"fmt"
+	"math/big"
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
+
+	"tech-test/internal/apierror"
 )
 
 // ParseQueryParams extracts and validates 'a' and 'b' query parameters
 func ParseQueryParams(r *http.Request) (*float64, *float64, error) {
-	aStr := r.URL.Query().Get("a")
-	bStr := r.URL.Query().Get("b")
-
-	if aStr == "" || bStr == "" {
-		return nil, nil, errors.New("both 'a' and 'b' query parameters are required")
+	args, err := ParseOperands(r, []string{"a", "b"})
+	if err != nil {
+		return nil, nil, err
 	}
+	return &args[0], &args[1], nil
+}
 
-	a, err := strconv.ParseFloat(aStr, 64)
+// ParseOperands extracts and validates one float query parameter per name, in order.
+// Operands may be decimals or fractions such as 1/3.
+func ParseOperands(r *http.Request, names []string) ([]float64, error) {
+	values, err := requiredParams(r, names)
 	if err != nil {
-		return nil, nil, errors.New("parameter 'a' must be a valid number")
+		return nil, err
 	}
 
-	b, err := strconv.ParseFloat(bStr, 64)
+	args := make([]float64, len(names))
+	for i, name := range names {
+		var ok bool
+		if args[i], ok = parseNumber(values[i]); !ok {
+			return nil, apierror.InvalidNumber(name)
+		}
+	}
+
+	return args, nil
+}
+
+// parseNumber parses a decimal number or a fraction of two integers such as "1/3"
+func parseNumber(s string) (float64, bool) {
+	if isFraction(s) {
+		r, ok := parseRat(s)
+		if !ok {
+			return 0, false
+		}
+		f, _ := r.Float64()
+		return f, true
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
+
+// rationalPattern is the grammar of an exact operand: a fraction of two decimal integers or a decimal number with an
+// optional exponent. big.Rat.SetString alone would also read base prefixes, taking 010/1 as octal 8 and 0x10 as 16.
+var rationalPattern = regexp.MustCompile(`^[+-]?(\d+/\d+|(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?)$`)
+
+// parseRat parses s as an arbitrary-precision rational written in decimal
+func parseRat(s string) (*big.Rat, bool) {
+	if !rationalPattern.MatchString(s) {
+		return nil, false
+	}
+	numerator, denominator, ok := strings.Cut(s, "/")
+	if !ok {
+		return new(big.Rat).SetString(s)
+	}
+	// Each part is parsed in base 10 explicitly, as SetString reads a leading 0 in a fraction as octal
+	num, _ := new(big.Int).SetString(numerator, 10)
+	den, _ := new(big.Int).SetString(denominator, 10)
+	if den.Sign() == 0 {
+		return nil, false
+	}
+	return new(big.Rat).SetFrac(num, den), true
+}
+
+// isFraction reports whether s is written as a fraction rather than a decimal
+func isFraction(s string) bool {
+	return strings.Contains(s, "/")
+}
+
+// ParseExactOperands extracts and validates one query parameter per name as an arbitrary-precision rational
+func ParseExactOperands(r *http.Request, names []string) ([]*big.Rat, error) {
+	values, err := requiredParams(r, names)
 	if err != nil {
-		return nil, nil, errors.New("parameter 'b' must be a valid number")
+		return nil, err
+	}
+
+	args := make([]*big.Rat, len(names))
+	for i, name := range names {
+		arg, ok := parseRat(values[i])
+		if !ok {
+			return nil, apierror.InvalidNumber(name)
+		}
+		args[i] = arg
+	}
+
+	return args, nil
+}
+
+// requiredParams returns the raw value of each named query parameter, reporting the first one that is absent
+func requiredParams(r *http.Request, names []string) ([]string, error) {
+	query := r.URL.Query()
+
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = query.Get(name)
+		if values[i] == "" {
+			return nil, apierror.MissingParameter(name, requiredParamsMessage(names))
+		}
+	}
+
+	return values, nil
+}
+
+// requiredParamsMessage phrases the requirement for names, e.g. "both 'a' and 'b' query parameters are required"
+func requiredParamsMessage(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+
+	switch len(quoted) {
+	case 1:
+		return quoted[0] + " query parameter is required"
+	case 2:
+		return "both " + quoted[0] + " and " + quoted[1] + " query parameters are required"
+	default:
+		return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1] + " query parameters are required"
+	}
+}
+
+// Operand lists are given as repeated 'x' parameters or as a single comma-separated 'values' parameter
+const (
+	repeatedParam = "x"
+	valuesParam   = "values"
+)
+
+// ParseOperandList extracts an operand list from the query; listed is false when none was supplied
+func ParseOperandList(r *http.Request) (values []float64, listed bool, err error) {
+	raw, name, listed, err := operandList(r)
+	if !listed || err != nil {
+		return nil, listed, err
+	}
+
+	values = make([]float64, len(raw))
+	for i, s := range raw {
+		var ok bool
+		if values[i], ok = parseNumber(s); !ok {
+			return nil, true, apierror.InvalidNumber(name)
+		}
+	}
+	return values, true, nil
+}
+
+// ParseExactOperandList is ParseOperandList for arbitrary-precision rationals
+func ParseExactOperandList(r *http.Request) (values []*big.Rat, listed bool, err error) {
+	raw, name, listed, err := operandList(r)
+	if !listed || err != nil {
+		return nil, listed, err
+	}
+
+	values = make([]*big.Rat, len(raw))
+	for i, s := range raw {
+		v, ok := parseRat(s)
+		if !ok {
+			return nil, true, apierror.InvalidNumber(name)
+		}
+		values[i] = v
+	}
+	return values, true, nil
+}
+
+// operandList returns the raw operands and the parameter they came from
+func operandList(r *http.Request) (raw []string, name string, listed bool, err error) {
+	query := r.URL.Query()
+	repeated, hasRepeated := query[repeatedParam]
+	_, hasValues := query[valuesParam]
+
+	switch {
+	case hasRepeated && hasValues:
+		return nil, "", true, apierror.InvalidParameter(valuesParam, "use either repeated 'x' parameters or 'values', not both")
+	case hasRepeated:
+		raw, name = repeated, repeatedParam
+	case hasValues:
+		raw, name = strings.Split(query.Get(valuesParam), ","), valuesParam
+	default:
+		return nil, "", false, nil
+	}
+
+	for i := range raw {
+		raw[i] = strings.TrimSpace(raw[i])
+		if raw[i] == "" {
+			return nil, name, true, apierror.MissingParameter(name, fmt.Sprintf("'%s' must not contain empty operands", name))
+		}
+	}
+	return raw, name, true, nil
+}
+
+// reservedParams are query parameters that control the response rather than supply operands
+var reservedParams = map[string]bool{
+	"expr":      true,
+	"format":    true,
+	"mode":      true,
+	"precision": true,
+	"render":    true,
+}
+
+// ParseExprParams extracts the 'expr' query parameter and binds every other non-reserved query parameter as a variable
+func ParseExprParams(r *http.Request) (string, map[string]float64, error) {
+	query := r.URL.Query()
+
+	expr := query.Get("expr")
+	if expr == "" {
+		return "", nil, apierror.MissingParameter("expr", "'expr' query parameter is required")
+	}
+
+	vars := make(map[string]float64, len(query))
+	for name := range query {
+		if reservedParams[name] {
+			continue
+		}
+		value, ok := parseNumber(query.Get(name))
+		if !ok {
+			return "", nil, apierror.InvalidNumber(name)
+		}
+		vars[name] = value
 	}
 
-	return &a, &b, nil
+	return expr, vars, nil
 }
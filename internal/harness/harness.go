@@ -0,0 +1,30 @@
+// Package harness starts the server the validation suites run against
+package harness
+
+import (
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"tech-test/internal/config"
+	"tech-test/internal/server"
+)
+
+// BaseURLEnv names an already running server to validate instead of starting one in-process
+const BaseURLEnv = "VALIDATION_BASE_URL"
+
+// Start returns the base URL for the suites and a function that stops anything Start started.
+// When VALIDATION_BASE_URL is set that server is used as-is; otherwise the app is served in-process
+// with the default configuration.
+func Start() (baseURL string, stop func(), err error) {
+	if url := os.Getenv(BaseURLEnv); url != "" {
+		return strings.TrimRight(url, "/"), func() {}, nil
+	}
+
+	app, err := server.New(config.Default())
+	if err != nil {
+		return "", nil, err
+	}
+	srv := httptest.NewServer(app)
+	return srv.URL, srv.Close, nil
+}
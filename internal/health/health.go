@@ -0,0 +1,146 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/handlers"
+)
+
+// checkTimeout bounds each dependency check so a hung check cannot hang the probe
+const checkTimeout = 2 * time.Second
+
+// Check is a named dependency check; a nil error means the dependency is healthy
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Checker reports liveness and readiness for the server
+type Checker struct {
+	version    string
+	operations []string
+	started    time.Time
+	draining   atomic.Bool
+
+	mu     sync.Mutex
+	checks []Check
+}
+
+// NewChecker returns a Checker reporting the given build version and enabled operations
+func NewChecker(version string, operations []string) *Checker {
+	return &Checker{version: version, operations: operations, started: time.Now()}
+}
+
+// AddCheck registers a dependency check that must pass for the server to be ready
+func (c *Checker) AddCheck(name string, run func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, Check{Name: name, Run: run})
+}
+
+// SetDraining marks the server as shutting down; it stays live but is no longer ready
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+type checkResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type report struct {
+	Status        string        `json:"status"`
+	Version       string        `json:"version"`
+	UptimeSeconds float64       `json:"uptime_seconds"`
+	Operations    []string      `json:"operations"`
+	Draining      bool          `json:"draining"`
+	Checks        []checkResult `json:"checks"`
+}
+
+// LivenessHandler serves /healthz: 200 whenever the process can answer, with check results for information only
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowGet(w, r) {
+			return
+		}
+		rep, _ := c.report(r.Context())
+		rep.Status = "ok"
+		write(w, http.StatusOK, rep)
+	})
+}
+
+// ReadinessHandler serves /readyz: 200 when every check passes and the server is not draining, 503 otherwise
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowGet(w, r) {
+			return
+		}
+		rep, ready := c.report(r.Context())
+		status := http.StatusOK
+		rep.Status = "ready"
+		if !ready {
+			status = http.StatusServiceUnavailable
+			rep.Status = "not_ready"
+		}
+		write(w, status, rep)
+	})
+}
+
+// report runs every check and reports whether the server is ready
+func (c *Checker) report(ctx context.Context) (report, bool) {
+	c.mu.Lock()
+	checks := append([]Check(nil), c.checks...)
+	c.mu.Unlock()
+
+	draining := c.draining.Load()
+	rep := report{
+		Version:       c.version,
+		UptimeSeconds: time.Since(c.started).Seconds(),
+		Operations:    c.operations,
+		Draining:      draining,
+		Checks:        make([]checkResult, len(checks)),
+	}
+
+	ready := !draining
+	for i, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := check.Run(checkCtx)
+		cancel()
+
+		rep.Checks[i] = checkResult{Name: check.Name, Status: "pass"}
+		if err != nil {
+			rep.Checks[i].Status = "fail"
+			rep.Checks[i].Error = err.Error()
+			ready = false
+		}
+	}
+
+	return rep, ready
+}
+
+func allowGet(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		handlers.WriteError(w, r, apierror.MethodNotAllowed(r.Method))
+		return false
+	}
+	return true
+}
+
+func write(w http.ResponseWriter, status int, rep report) {
+	encoded, err := json.Marshal(rep)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	w.Write(encoded)
+}
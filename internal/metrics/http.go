@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+)
+
+// HTTPMetrics are the request metrics recorded for every operation
+type HTTPMetrics struct {
+	requests *CounterVec
+	errors   *CounterVec
+	duration *HistogramVec
+}
+
+// NewHTTPMetrics registers the request metrics on r
+func NewHTTPMetrics(r *Registry) *HTTPMetrics {
+	return &HTTPMetrics{
+		requests: r.NewCounterVec("techtest_http_requests_total",
+			"Requests handled, by operation and status code.", "operation", "code"),
+		errors: r.NewCounterVec("techtest_http_request_errors_total",
+			"Requests answered with a 4xx or 5xx status, by operation and status code.", "operation", "code"),
+		duration: r.NewHistogramVec("techtest_http_request_duration_seconds",
+			"Request latency, by operation.", DefaultBuckets, "operation"),
+	}
+}
+
+// Observe records one request
+func (m *HTTPMetrics) Observe(operation string, status int, latency time.Duration) {
+	code := strconv.Itoa(status)
+	m.requests.Inc(operation, code)
+	if status >= 400 {
+		m.errors.Inc(operation, code)
+	}
+	m.duration.Observe(latency.Seconds(), operation)
+}
@@ -0,0 +1,268 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/handlers"
+)
+
+// collector writes one or more metric families in the Prometheus text exposition format
+type collector interface {
+	collect(w *bufio.Writer)
+}
+
+// Registry holds metrics and renders them in the Prometheus text exposition format (version 0.0.4)
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// WriteTo writes every registered metric to w
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, c := range collectors {
+		c.collect(bw)
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// Handler serves the registry for scraping
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			handlers.WriteError(w, req, apierror.MethodNotAllowed(req.Method))
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteTo(w)
+	})
+}
+
+// CounterVec is a counter partitioned by label values
+type CounterVec struct {
+	name, help string
+	labels     []string
+
+	mu     sync.Mutex
+	values map[string]*counterSeries
+}
+
+type counterSeries struct {
+	labelValues []string
+	value       float64
+}
+
+// NewCounterVec registers a counter with the given label names
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{name: name, help: help, labels: labels, values: make(map[string]*counterSeries)}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the series identified by labelValues, given in label order
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds v to the series identified by labelValues
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	key := seriesKey(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.values[key]
+	if !ok {
+		s = &counterSeries{labelValues: append([]string(nil), labelValues...)}
+		c.values[key] = s
+	}
+	s.value += v
+}
+
+func (c *CounterVec) collect(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	for _, key := range sortedKeys(c.values) {
+		s := c.values[key]
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labels, s.labelValues), formatValue(s.value))
+	}
+}
+
+// HistogramVec is a histogram partitioned by label values
+type HistogramVec struct {
+	name, help string
+	labels     []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	values map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// DefaultBuckets suit request latencies measured in seconds
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// NewHistogramVec registers a histogram with the given upper bucket bounds and label names
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	h := &HistogramVec{name: name, help: help, labels: labels, buckets: buckets, values: make(map[string]*histogramSeries)}
+	r.register(h)
+	return h
+}
+
+// Observe records v in the series identified by labelValues
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	key := seriesKey(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.values[key]
+	if !ok {
+		s = &histogramSeries{labelValues: append([]string(nil), labelValues...), counts: make([]uint64, len(h.buckets))}
+		h.values[key] = s
+	}
+	for i, bound := range h.buckets {
+		if v <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += v
+}
+
+func (h *HistogramVec) collect(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+	bucketLabels := append(append([]string(nil), h.labels...), "le")
+	for _, key := range sortedKeys(h.values) {
+		s := h.values[key]
+		for i, bound := range h.buckets {
+			values := append(append([]string(nil), s.labelValues...), formatValue(bound))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, values), s.counts[i])
+		}
+		values := append(append([]string(nil), s.labelValues...), "+Inf")
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, values), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labels, s.labelValues), formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labels, s.labelValues), s.count)
+	}
+}
+
+// valueFunc is an unlabelled metric whose value is read when the registry is scraped
+type valueFunc struct {
+	name, help, kind string
+	fn               func() float64
+}
+
+// NewGaugeFunc registers a gauge computed by fn at scrape time
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	r.register(&valueFunc{name: name, help: help, kind: "gauge", fn: fn})
+}
+
+// NewCounterFunc registers a counter read from fn at scrape time; fn must never decrease
+func (r *Registry) NewCounterFunc(name, help string, fn func() float64) {
+	r.register(&valueFunc{name: name, help: help, kind: "counter", fn: fn})
+}
+
+func (v *valueFunc) collect(w *bufio.Writer) {
+	writeHeader(w, v.name, v.help, v.kind)
+	fmt.Fprintf(w, "%s %s\n", v.name, formatValue(v.fn()))
+}
+
+func writeHeader(w *bufio.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(labelValueEscaper.Replace(values[i]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+// seriesKey joins label values with a separator that cannot appear in valid UTF-8
+func seriesKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// RegisterRuntime adds Go runtime gauges: goroutines, heap and GC statistics
+func RegisterRuntime(r *Registry) {
+	// ReadMemStats stops the world, so read it at most once a second and share the result across metrics
+	var (
+		mu      sync.Mutex
+		stats   runtime.MemStats
+		updated time.Time
+	)
+	memStats := func() runtime.MemStats {
+		mu.Lock()
+		defer mu.Unlock()
+		if time.Since(updated) > time.Second {
+			runtime.ReadMemStats(&stats)
+			updated = time.Now()
+		}
+		return stats
+	}
+
+	r.NewGaugeFunc("go_goroutines", "Number of goroutines that currently exist.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+	r.NewGaugeFunc("go_memstats_alloc_bytes", "Number of bytes allocated and still in use.", func() float64 {
+		return float64(memStats().Alloc)
+	})
+	r.NewGaugeFunc("go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.", func() float64 {
+		return float64(memStats().HeapInuse)
+	})
+	r.NewGaugeFunc("go_memstats_sys_bytes", "Number of bytes obtained from the system.", func() float64 {
+		return float64(memStats().Sys)
+	})
+	r.NewCounterFunc("go_gc_cycles_total", "Number of completed GC cycles.", func() float64 {
+		return float64(memStats().NumGC)
+	})
+	r.NewCounterFunc("go_gc_pause_seconds_total", "Total time spent in stop-the-world GC pauses.", func() float64 {
+		return time.Duration(memStats().PauseTotalNs).Seconds()
+	})
+}
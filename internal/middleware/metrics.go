@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"tech-test/internal/metrics"
+)
+
+// Metrics records the count, errors and latency of each request against its operation.
+// It must wrap the ServeMux directly so the matched route pattern is visible afterwards;
+// requests that match no route are grouped under "unmatched" to keep label cardinality bounded.
+func Metrics(m *metrics.HTTPMetrics, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(rec, r)
+
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		operation := strings.TrimPrefix(r.Pattern, "/")
+		if operation == "" {
+			operation = "unmatched"
+		}
+		m.Observe(operation, rec.status, time.Since(start))
+	})
+}
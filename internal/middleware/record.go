@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"tech-test/internal/recording"
+)
+
+// bodyRecorder captures the status and up to recording.MaxBodySize bytes of the response body
+type bodyRecorder struct {
+	statusRecorder
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	if room := recording.MaxBodySize - r.body.Len(); room < len(b) {
+		r.body.Write(b[:max(room, 0)])
+		r.truncated = true
+	} else {
+		r.body.Write(b)
+	}
+	return r.statusRecorder.Write(b)
+}
+
+// Record writes every request and its response to rec as one JSON line.
+// Bodies longer than recording.MaxBodySize are cut short in the record but reach the handler and client untouched;
+// failures to write the record are logged and never affect the response.
+func Record(logger *slog.Logger, rec *recording.Recorder, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, recording.MaxBodySize+1))
+		if err != nil {
+			logger.Error("recording request body", "error", err)
+		}
+		truncated := len(body) > recording.MaxBodySize
+		// Hand the handler the bytes already read followed by whatever is left unread
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+		if truncated {
+			body = body[:recording.MaxBodySize]
+		}
+
+		resp := &bodyRecorder{statusRecorder: statusRecorder{ResponseWriter: w}}
+		next.ServeHTTP(resp, r)
+
+		if resp.status == 0 {
+			resp.status = http.StatusOK
+		}
+		err = rec.Record(recording.Entry{
+			Time:           start.UTC(),
+			RequestID:      RequestIDFromContext(r.Context()),
+			Method:         r.Method,
+			URL:            r.URL.RequestURI(),
+			Header:         r.Header,
+			Body:           string(body),
+			Status:         resp.status,
+			ResponseHeader: w.Header(),
+			Response:       resp.body.String(),
+			Truncated:      truncated || resp.truncated,
+		})
+		if err != nil {
+			logger.Error("recording request", "error", err)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+// Package mutation generates small, plausible bugs in Go source so test suites can be checked for catching them
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind groups mutants by the sort of bug they plant
+type Kind string
+
+const (
+	// KindOperator swaps an arithmetic, comparison or logical operator
+	KindOperator Kind = "operator"
+	// KindErrorCheck disables an `if err != nil` branch
+	KindErrorCheck Kind = "error_check"
+	// KindFormat changes how a value is formatted
+	KindFormat Kind = "format"
+)
+
+// Mutant is one textual change to a source file
+type Mutant struct {
+	File        string
+	Line        int
+	Column      int
+	Kind        Kind
+	Description string
+	offset      int
+	length      int
+	replacement string
+}
+
+func (m Mutant) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", m.File, m.Line, m.Column, m.Description)
+}
+
+// Apply returns src with the mutation made
+func (m Mutant) Apply(src []byte) []byte {
+	out := make([]byte, 0, len(src)-m.length+len(m.replacement))
+	out = append(out, src[:m.offset]...)
+	out = append(out, m.replacement...)
+	return append(out, src[m.offset+m.length:]...)
+}
+
+// operatorSwaps maps each operator to the one that replaces it
+var operatorSwaps = map[token.Token]token.Token{
+	token.ADD:  token.SUB,
+	token.SUB:  token.ADD,
+	token.MUL:  token.QUO,
+	token.QUO:  token.MUL,
+	token.LSS:  token.LEQ,
+	token.LEQ:  token.LSS,
+	token.GTR:  token.GEQ,
+	token.GEQ:  token.GTR,
+	token.EQL:  token.NEQ,
+	token.NEQ:  token.EQL,
+	token.LAND: token.LOR,
+	token.LOR:  token.LAND,
+}
+
+// fixedPrecision matches a %.Nf style verb
+var fixedPrecision = regexp.MustCompile(`%\.(\d+)f`)
+
+// Generate lists the mutants of a Go source file. Mutants always compile unless the swap changes a type,
+// e.g. + on strings becoming -, which the runner reports as not viable.
+func Generate(filename string, src []byte) ([]Mutant, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutants []Mutant
+	add := func(pos token.Pos, length int, replacement string, kind Kind, description string) {
+		p := fset.Position(pos)
+		mutants = append(mutants, Mutant{
+			File:        filename,
+			Line:        p.Line,
+			Column:      p.Column,
+			Kind:        kind,
+			Description: description,
+			offset:      p.Offset,
+			length:      length,
+			replacement: replacement,
+		})
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BinaryExpr:
+			if swap, ok := operatorSwaps[n.Op]; ok {
+				add(n.OpPos, len(n.Op.String()), swap.String(), KindOperator,
+					fmt.Sprintf("replace %s with %s", n.Op, swap))
+			}
+		case *ast.IfStmt:
+			// `false && cond` keeps variables declared in the if's init statement used
+			if isErrCheck(n.Cond) {
+				add(n.Cond.Pos(), 0, "false && ", KindErrorCheck, "never take the err != nil branch")
+			}
+		case *ast.BasicLit:
+			if n.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(n.Value)
+			if err != nil {
+				return true
+			}
+			if loc := fixedPrecision.FindStringSubmatchIndex(value); loc != nil {
+				digits, _ := strconv.Atoi(value[loc[2]:loc[3]])
+				mutated := value[:loc[2]] + strconv.Itoa(digits+1) + value[loc[3]:]
+				add(n.Pos(), len(n.Value), strconv.Quote(mutated), KindFormat,
+					fmt.Sprintf("print %d decimal places instead of %d", digits+1, digits))
+			}
+			if strings.Contains(value, "%s") {
+				add(n.Pos(), len(n.Value), strconv.Quote(strings.Replace(value, "%s", "%q", 1)), KindFormat,
+					"quote the first %s operand")
+			}
+		case *ast.CallExpr:
+			// strconv.FormatFloat(v, 'f', prec, bits): switch fixed-point to exponent notation
+			if isCall(n, "strconv", "FormatFloat") && len(n.Args) == 4 {
+				if lit, ok := n.Args[1].(*ast.BasicLit); ok && lit.Kind == token.CHAR && lit.Value == "'f'" {
+					add(lit.Pos(), len(lit.Value), "'e'", KindFormat, "format floats in exponent notation")
+				}
+			}
+		}
+		return true
+	})
+
+	sort.SliceStable(mutants, func(i, j int) bool { return mutants[i].offset < mutants[j].offset })
+	return mutants, nil
+}
+
+func isErrCheck(cond ast.Expr) bool {
+	bin, ok := cond.(*ast.BinaryExpr)
+	if !ok || bin.Op != token.NEQ {
+		return false
+	}
+	x, ok := bin.X.(*ast.Ident)
+	if !ok || x.Name != "err" {
+		return false
+	}
+	y, ok := bin.Y.(*ast.Ident)
+	return ok && y.Name == "nil"
+}
+
+func isCall(call *ast.CallExpr, pkg, name string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	id, ok := sel.X.(*ast.Ident)
+	return ok && id.Name == pkg
+}
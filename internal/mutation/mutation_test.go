@@ -0,0 +1,107 @@
+package mutation
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+const testSource = `package sample
+
+import "strconv"
+
+// é moves byte offsets away from rune offsets
+func Add(a, b float64) float64 { return a + b }
+
+func Format(v float64) string {
+	if v >= 0 && v < 1 {
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	}
+	return fmt.Sprintf("%.2f is %s", v, "é")
+}
+
+func Load(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	return nil
+}
+`
+
+func TestGenerate(t *testing.T) {
+	want := []struct {
+		line, column int
+		kind         Kind
+		description  string
+		// mutatedLine is the mutant's line after Apply
+		mutatedLine string
+	}{
+		{6, 43, KindOperator, "replace + with -",
+			"func Add(a, b float64) float64 { return a - b }"},
+		{9, 7, KindOperator, "replace >= with >",
+			"\tif v > 0 && v < 1 {"},
+		{9, 12, KindOperator, "replace && with ||",
+			"\tif v >= 0 || v < 1 {"},
+		{9, 17, KindOperator, "replace < with <=",
+			"\tif v >= 0 && v <= 1 {"},
+		{10, 33, KindFormat, "format floats in exponent notation",
+			"\t\treturn strconv.FormatFloat(v, 'e', 2, 64)"},
+		{12, 21, KindFormat, "print 3 decimal places instead of 2",
+			"\treturn fmt.Sprintf(\"%.3f is %s\", v, \"é\")"},
+		{12, 21, KindFormat, "quote the first %s operand",
+			"\treturn fmt.Sprintf(\"%.2f is %q\", v, \"é\")"},
+		{16, 30, KindErrorCheck, "never take the err != nil branch",
+			"\tif _, err := os.Stat(path); false && err != nil {"},
+		{16, 34, KindOperator, "replace != with ==",
+			"\tif _, err := os.Stat(path); err == nil {"},
+	}
+
+	mutants, err := Generate("sample.go", []byte(testSource))
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(mutants) != len(want) {
+		for _, m := range mutants {
+			t.Log(m)
+		}
+		t.Fatalf("Expected %d mutants, got %d", len(want), len(mutants))
+	}
+
+	for i, w := range want {
+		m := mutants[i]
+		if m.File != "sample.go" || m.Line != w.line || m.Column != w.column || m.Kind != w.kind || m.Description != w.description {
+			t.Errorf("Expected mutant %d to be sample.go:%d:%d: %s (%s), got %s (%s)", i, w.line, w.column, w.description, w.kind, m, m.Kind)
+			continue
+		}
+
+		mutated := m.Apply([]byte(testSource))
+		if got := line(mutated, w.line); got != w.mutatedLine {
+			t.Errorf("%s: expected line %d to become %q, got %q", m, w.line, w.mutatedLine, got)
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), "sample.go", mutated, 0); err != nil {
+			t.Errorf("%s: mutated source does not parse: %v", m, err)
+		}
+	}
+}
+
+func TestGenerateInvalidSource(t *testing.T) {
+	if _, err := Generate("broken.go", []byte("package broken\nfunc {")); err == nil {
+		t.Error("Expected an error for source that does not parse")
+	}
+}
+
+// line returns the 1-based line n of src without its newline
+func line(src []byte, n int) string {
+	start := 0
+	for ; n > 1; n-- {
+		for src[start] != '\n' {
+			start++
+		}
+		start++
+	}
+	end := start
+	for end < len(src) && src[end] != '\n' {
+		end++
+	}
+	return string(src[start:end])
+}
@@ -0,0 +1,70 @@
+package mutation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Outcome is what the test suites made of a mutant
+type Outcome string
+
+const (
+	// Killed means a test failed, so the suites catch this bug
+	Killed Outcome = "killed"
+	// Survived means every test passed with the bug in place
+	Survived Outcome = "survived"
+	// NotViable means the mutant did not compile and says nothing about the tests
+	NotViable Outcome = "not_viable"
+	// TimedOut means the tests did not finish in time, which usually means the bug caused a hang
+	TimedOut Outcome = "timed_out"
+)
+
+// Test runs `go test` on pkgs with the mutant swapped in for its file through a build overlay,
+// so the working tree is never modified and mutants can be tested concurrently.
+// src is the original content of the mutant's file.
+func Test(ctx context.Context, m Mutant, src []byte, pkgs []string) (Outcome, error) {
+	dir, err := os.MkdirTemp("", "mutant-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	abs, err := filepath.Abs(m.File)
+	if err != nil {
+		return "", err
+	}
+	mutated := filepath.Join(dir, filepath.Base(m.File))
+	if err := os.WriteFile(mutated, m.Apply(src), 0o644); err != nil {
+		return "", err
+	}
+	overlay, err := json.Marshal(map[string]map[string]string{"Replace": {abs: mutated}})
+	if err != nil {
+		return "", err
+	}
+	overlayPath := filepath.Join(dir, "overlay.json")
+	if err := os.WriteFile(overlayPath, overlay, 0o644); err != nil {
+		return "", err
+	}
+
+	args := append([]string{"test", "-count=1", "-failfast", "-vet=off", "-overlay", overlayPath}, pkgs...)
+	cmd := exec.CommandContext(ctx, "go", args...)
+	out, err := cmd.CombinedOutput()
+	switch {
+	case ctx.Err() != nil:
+		return TimedOut, nil
+	case err == nil:
+		return Survived, nil
+	case bytes.Contains(out, []byte("[build failed]")) || bytes.Contains(out, []byte("[setup failed]")):
+		return NotViable, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return Killed, nil
+	}
+	return "", err
+}
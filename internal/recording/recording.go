@@ -0,0 +1,97 @@
+// Package recording captures request/response pairs as JSON lines and replays them against another server
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MaxBodySize bounds how much of each request and response body is kept in a record
+const MaxBodySize = 1 << 20
+
+// redacted replaces the values of headers that carry credentials
+const redacted = "[REDACTED]"
+
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Entry is one recorded request and the response it received
+type Entry struct {
+	Time           time.Time   `json:"time"`
+	RequestID      string      `json:"request_id,omitempty"`
+	Method         string      `json:"method"`
+	URL            string      `json:"url"`
+	Header         http.Header `json:"header,omitempty"`
+	Body           string      `json:"body,omitempty"`
+	Status         int         `json:"status"`
+	ResponseHeader http.Header `json:"response_header,omitempty"`
+	Response       string      `json:"response"`
+	// Truncated is set when either body exceeded MaxBodySize; such entries cannot be replayed faithfully
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Recorder appends entries to a writer as JSON lines. It is safe for concurrent use.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewRecorder returns a Recorder writing to w
+func NewRecorder(w io.Writer) *Recorder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &Recorder{enc: enc}
+}
+
+// Record writes e as a single line, redacting credential headers first
+func (r *Recorder) Record(e Entry) error {
+	e.Header = redact(e.Header)
+	e.ResponseHeader = redact(e.ResponseHeader)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enc.Encode(e)
+}
+
+func redact(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	h = h.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := h[name]; ok {
+			h[name] = []string{redacted}
+		}
+	}
+	return h
+}
+
+// ReadEntries parses a JSON lines recording, skipping blank lines
+func ReadEntries(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	// A line holds two bodies of up to MaxBodySize each, JSON-escaped
+	scanner.Buffer(make([]byte, 64*1024), 16*MaxBodySize)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if e.Method == "" || e.URL == "" {
+			return nil, fmt.Errorf("line %d: not a recorded request: missing method or url", line)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
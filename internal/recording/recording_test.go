@@ -0,0 +1,72 @@
+package recording
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRecordRedactsAndReadsBack(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+
+	header := http.Header{"Authorization": {"Bearer secret"}, "Accept": {"application/json"}}
+	entries := []Entry{
+		{Method: http.MethodGet, URL: "/add?a=1&b=2", Header: header, Status: 200, Response: "3.00"},
+		{Method: http.MethodPost, URL: "/batch", Body: `[{"operation":"add","a":1,"b":2}]`, Status: 200,
+			ResponseHeader: http.Header{"Set-Cookie": {"session=abc"}}, Response: `[{"result":3}]`},
+	}
+	for _, e := range entries {
+		if err := rec.Record(e); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	if strings.Contains(buf.String(), "secret") || strings.Contains(buf.String(), "session=abc") {
+		t.Errorf("Expected credentials to be redacted, got %s", buf.String())
+	}
+	if header.Get("Authorization") != "Bearer secret" {
+		t.Error("Expected Record not to modify the caller's header")
+	}
+
+	read, err := ReadEntries(strings.NewReader(buf.String() + "\n"))
+	if err != nil {
+		t.Fatalf("ReadEntries: %v", err)
+	}
+	if len(read) != len(entries) {
+		t.Fatalf("Expected %d entries, got %d", len(entries), len(read))
+	}
+	if got := read[0].Header.Get("Authorization"); got != redacted {
+		t.Errorf("Expected a redacted Authorization header, got %q", got)
+	}
+	if got := read[0].Header.Get("Accept"); got != "application/json" {
+		t.Errorf("Expected the Accept header to be kept, got %q", got)
+	}
+	if got := read[1].ResponseHeader.Get("Set-Cookie"); got != redacted {
+		t.Errorf("Expected a redacted Set-Cookie header, got %q", got)
+	}
+	if read[1].Body != entries[1].Body || read[1].Response != entries[1].Response {
+		t.Errorf("Expected bodies to round-trip, got %q and %q", read[1].Body, read[1].Response)
+	}
+}
+
+func TestReadEntriesInvalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "malformed JSON", input: "{\"method\":\"GET\",\"url\":\"/ping\"}\n{not json}\n", wantErr: "line 2:"},
+		{name: "missing url", input: `{"method":"GET"}`, wantErr: "line 1: not a recorded request"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ReadEntries(strings.NewReader(tc.input))
+			if err == nil || !strings.HasPrefix(err.Error(), tc.wantErr) {
+				t.Errorf("Expected an error starting %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// skipHeaders are not resent on replay: the client sets them itself or they would be meaningless
+var skipHeaders = map[string]bool{
+	"Host":              true,
+	"Content-Length":    true,
+	"Connection":        true,
+	"Transfer-Encoding": true,
+	"Accept-Encoding":   true,
+}
+
+// Replay sends the request recorded in e to baseURL and returns the response as a new entry
+func Replay(ctx context.Context, client *http.Client, baseURL string, e Entry) (Entry, error) {
+	req, err := http.NewRequestWithContext(ctx, e.Method, baseURL+e.URL, bytes.NewReader([]byte(e.Body)))
+	if err != nil {
+		return Entry{}, err
+	}
+	for name, values := range e.Header {
+		if skipHeaders[http.CanonicalHeaderKey(name)] || (len(values) == 1 && values[0] == redacted) {
+			continue
+		}
+		req.Header[name] = values
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Entry{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize+1))
+	if err != nil {
+		return Entry{}, err
+	}
+
+	replayed := e
+	replayed.Status = resp.StatusCode
+	replayed.ResponseHeader = resp.Header
+	replayed.Response = string(body)
+	replayed.Truncated = len(body) > MaxBodySize
+	return replayed, nil
+}
+
+// Diff lists the differences between a recorded response and its replay: status, content type and body.
+// JSON bodies are compared by value, so key order and whitespace do not count as differences; other bodies must match exactly.
+func Diff(recorded, replayed Entry) []string {
+	var diffs []string
+
+	if recorded.Status != replayed.Status {
+		diffs = append(diffs, fmt.Sprintf("status: %d -> %d", recorded.Status, replayed.Status))
+	}
+
+	recordedType := mediaType(recorded.ResponseHeader)
+	replayedType := mediaType(replayed.ResponseHeader)
+	if recordedType != replayedType {
+		diffs = append(diffs, fmt.Sprintf("content type: %q -> %q", recordedType, replayedType))
+	}
+
+	if !sameBody(recorded.Response, replayed.Response, isJSON(recordedType) && isJSON(replayedType)) {
+		diffs = append(diffs, fmt.Sprintf("body: %q -> %q", recorded.Response, replayed.Response))
+	}
+
+	return diffs
+}
+
+func mediaType(h http.Header) string {
+	mediatype, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return h.Get("Content-Type")
+	}
+	return mediatype
+}
+
+// isJSON reports whether mediatype is application/json or a JSON-based type such as application/problem+json
+func isJSON(mediatype string) bool {
+	return mediatype == "application/json" || strings.HasSuffix(mediatype, "+json")
+}
+
+func sameBody(a, b string, asJSON bool) bool {
+	if a == b {
+		return true
+	}
+	if !asJSON {
+		return false
+	}
+	var av, bv any
+	if json.Unmarshal([]byte(a), &av) != nil || json.Unmarshal([]byte(b), &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
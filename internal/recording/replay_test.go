@@ -0,0 +1,129 @@
+package recording
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	jsonHeader := http.Header{"Content-Type": {"application/json"}}
+	textHeader := http.Header{"Content-Type": {"text/plain; charset=utf-8"}}
+
+	testCases := []struct {
+		name      string
+		recorded  Entry
+		replayed  Entry
+		wantDiffs []string
+	}{
+		{
+			name:     "identical text",
+			recorded: Entry{Status: 200, ResponseHeader: textHeader, Response: "5.00"},
+			replayed: Entry{Status: 200, ResponseHeader: textHeader, Response: "5.00"},
+		},
+		{
+			name:     "JSON key order and whitespace",
+			recorded: Entry{Status: 200, ResponseHeader: jsonHeader, Response: `{"operation":"add","a":2,"b":3,"result":5}`},
+			replayed: Entry{Status: 200, ResponseHeader: jsonHeader, Response: "{\"result\": 5, \"b\": 3, \"a\": 2, \"operation\": \"add\"}\n"},
+		},
+		{
+			name:     "JSON numbers compared by value",
+			recorded: Entry{Status: 200, ResponseHeader: jsonHeader, Response: `{"result":5}`},
+			replayed: Entry{Status: 200, ResponseHeader: jsonHeader, Response: `{"result":5.0}`},
+		},
+		{
+			name:      "JSON value changed",
+			recorded:  Entry{Status: 200, ResponseHeader: jsonHeader, Response: `{"result":5}`},
+			replayed:  Entry{Status: 200, ResponseHeader: jsonHeader, Response: `{"result":6}`},
+			wantDiffs: []string{`body: "{\"result\":5}" -> "{\"result\":6}"`},
+		},
+		{
+			name:     "problem+json compared by value",
+			recorded: Entry{Status: 400, ResponseHeader: http.Header{"Content-Type": {"application/problem+json"}}, Response: `{"status":400,"title":"Bad Request"}`},
+			replayed: Entry{Status: 400, ResponseHeader: http.Header{"Content-Type": {"application/problem+json"}}, Response: `{"title":"Bad Request","status":400}`},
+		},
+		{
+			name:      "text whitespace counts",
+			recorded:  Entry{Status: 200, ResponseHeader: textHeader, Response: "5.00"},
+			replayed:  Entry{Status: 200, ResponseHeader: textHeader, Response: "5.00\n"},
+			wantDiffs: []string{`body: "5.00" -> "5.00\n"`},
+		},
+		{
+			name:      "text numbers compared as text",
+			recorded:  Entry{Status: 200, ResponseHeader: textHeader, Response: "5.00"},
+			replayed:  Entry{Status: 200, ResponseHeader: textHeader, Response: "5"},
+			wantDiffs: []string{`body: "5.00" -> "5"`},
+		},
+		{
+			name:     "content type parameters ignored",
+			recorded: Entry{Status: 200, ResponseHeader: http.Header{"Content-Type": {"text/plain"}}, Response: "5.00"},
+			replayed: Entry{Status: 200, ResponseHeader: textHeader, Response: "5.00"},
+		},
+		{
+			name:     "status and content type changed",
+			recorded: Entry{Status: 200, ResponseHeader: textHeader, Response: "5.00"},
+			replayed: Entry{Status: 400, ResponseHeader: http.Header{"Content-Type": {"application/problem+json"}}, Response: "5.00"},
+			wantDiffs: []string{
+				"status: 200 -> 400",
+				`content type: "text/plain" -> "application/problem+json"`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diffs := Diff(tc.recorded, tc.replayed)
+			if !slices.Equal(diffs, tc.wantDiffs) {
+				t.Errorf("Expected diffs %q, got %q", tc.wantDiffs, diffs)
+			}
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Clone(context.Background())
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte("5.00"))
+	}))
+	defer srv.Close()
+
+	recorded := Entry{
+		Method: http.MethodGet,
+		URL:    "/add?a=2&b=3",
+		Header: http.Header{
+			"Accept":        {"text/plain"},
+			"Authorization": {redacted},
+			"Host":          {"recorded.example"},
+		},
+		Status:   200,
+		Response: "5.00",
+	}
+	replayed, err := Replay(context.Background(), srv.Client(), srv.URL, recorded)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if got.URL.RequestURI() != recorded.URL {
+		t.Errorf("Expected request to %s, got %s", recorded.URL, got.URL.RequestURI())
+	}
+	if got.Header.Get("Accept") != "text/plain" {
+		t.Errorf("Expected the recorded Accept header to be resent, got %q", got.Header.Get("Accept"))
+	}
+	if _, ok := got.Header["Authorization"]; ok {
+		t.Error("Expected a redacted Authorization header not to be resent")
+	}
+	if strings.Contains(got.Host, "recorded.example") {
+		t.Errorf("Expected the recorded Host not to be resent, got %q", got.Host)
+	}
+	if replayed.Status != 200 || replayed.Response != "5.00" || replayed.Truncated {
+		t.Errorf("Expected a 200 '5.00' replay, got %d %q (truncated %v)", replayed.Status, replayed.Response, replayed.Truncated)
+	}
+	if diffs := Diff(recorded, replayed); len(diffs) != 1 || !strings.HasPrefix(diffs[0], "content type:") {
+		t.Errorf("Expected only the content type to differ from a recording without one, got %q", diffs)
+	}
+}
@@ -0,0 +1,37 @@
+package selftest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"tech-test/internal/apierror"
+	"tech-test/internal/handlers"
+)
+
+// Handler serves the latest report on GET and re-runs the vectors on POST
+func (r *Runner) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var report Report
+		switch req.Method {
+		case http.MethodGet:
+			report = r.Report()
+		case http.MethodPost:
+			report = r.Rerun()
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			handlers.WriteError(w, req, apierror.MethodNotAllowed(req.Method))
+			return
+		}
+
+		encoded, err := json.Marshal(report)
+		if err != nil {
+			handlers.WriteError(w, req, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		w.Write(encoded)
+	})
+}
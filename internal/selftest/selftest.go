@@ -0,0 +1,212 @@
+package selftest
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"tech-test/internal/domain"
+)
+
+//go:embed vectors.json
+var defaultVectors []byte
+
+// defaultTolerance absorbs float rounding when a vector does not set its own tolerance
+const defaultTolerance = 1e-9
+
+// domainErrors names the domain errors a vector may expect
+var domainErrors = map[string]error{
+	"division_by_zero": domain.ErrDivisionByZero,
+	"overflow":         domain.ErrOverflow,
+	"not_a_number":     domain.ErrNotANumber,
+	"negative_root":    domain.ErrNegativeRoot,
+	"log_non_positive": domain.ErrLogNonPositive,
+	"out_of_domain":    domain.ErrOutOfDomain,
+	"no_sign_change":   domain.ErrNoSignChange,
+	"no_convergence":   domain.ErrNoConvergence,
+}
+
+// Vector is a known input and its expected result or domain error.
+// Args longer or shorter than the operation's arity exercise its fold.
+type Vector struct {
+	Operation string    `json:"operation"`
+	Args      []float64 `json:"args"`
+	// Mode names a variant of the operation, e.g. "euclidean" for mod
+	Mode      string  `json:"mode,omitempty"`
+	Want      float64 `json:"want"`
+	WantError string  `json:"want_error,omitempty"`
+	// Tolerance is the allowed relative error; unset means the default of 1e-9 and zero demands an exact match
+	Tolerance *float64 `json:"tolerance,omitempty"`
+}
+
+// Load reads vectors from a JSON file, or returns the built-in vectors when path is empty
+func Load(path string) ([]Vector, error) {
+	data := defaultVectors
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("reading self-test vectors: %w", err)
+		}
+	}
+
+	var vectors []Vector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		return nil, fmt.Errorf("parsing self-test vectors: %w", err)
+	}
+	for i, v := range vectors {
+		if _, ok := domainErrors[v.WantError]; v.WantError != "" && !ok {
+			return nil, fmt.Errorf("self-test vector %d: unknown want_error %q", i, v.WantError)
+		}
+	}
+	return vectors, nil
+}
+
+// Result is the outcome of one vector
+type Result struct {
+	Vector
+	Got    *float64 `json:"got,omitempty"`
+	Error  string   `json:"error,omitempty"`
+	Passed bool     `json:"passed"`
+	// Skipped is set when the vector's operation is not enabled
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+// Report summarises a self-test run
+type Report struct {
+	OK      bool      `json:"ok"`
+	RanAt   time.Time `json:"ran_at"`
+	Passed  int       `json:"passed"`
+	Failed  int       `json:"failed"`
+	Skipped int       `json:"skipped"`
+	Results []Result  `json:"results"`
+}
+
+// Err summarises the failures in r, or returns nil when r is OK
+func (r Report) Err() error {
+	var errs []error
+	for _, res := range r.Results {
+		if !res.Passed && !res.Skipped {
+			errs = append(errs, fmt.Errorf("%s%v: %s", res.Operation, res.Args, res.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run checks every vector against the registry
+func Run(registry *domain.Registry, vectors []Vector) Report {
+	report := Report{RanAt: time.Now().UTC(), Results: make([]Result, len(vectors))}
+
+	for i, v := range vectors {
+		res := run(registry, v)
+		switch {
+		case res.Skipped:
+			report.Skipped++
+		case res.Passed:
+			report.Passed++
+		default:
+			report.Failed++
+		}
+		report.Results[i] = res
+	}
+	report.OK = report.Failed == 0
+	return report
+}
+
+func run(registry *domain.Registry, v Vector) Result {
+	res := Result{Vector: v}
+
+	op, ok := registry.Lookup(v.Operation)
+	if !ok {
+		res.Skipped = true
+		return res
+	}
+	if v.Mode != "" {
+		if op, ok = op.WithMode(v.Mode); !ok {
+			res.Error = fmt.Sprintf("operation has no mode %q", v.Mode)
+			return res
+		}
+	}
+
+	apply := op.Apply
+	if len(v.Args) != op.Arity() {
+		if op.Fold == nil || len(v.Args) == 0 {
+			res.Error = fmt.Sprintf("vector has %d args, operation takes %d", len(v.Args), op.Arity())
+			return res
+		}
+		apply = op.Fold
+	}
+
+	got, err := apply(v.Args)
+	if v.WantError != "" {
+		if errors.Is(err, domainErrors[v.WantError]) {
+			res.Passed = true
+			return res
+		}
+		if err != nil {
+			res.Error = fmt.Sprintf("got error %q, want %s", err, v.WantError)
+		} else {
+			res.Got = &got
+			res.Error = fmt.Sprintf("got %v, want %s", got, v.WantError)
+		}
+		return res
+	}
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	res.Got = &got
+	tolerance := defaultTolerance
+	if v.Tolerance != nil {
+		tolerance = *v.Tolerance
+	}
+	if math.Abs(got-v.Want) > tolerance*math.Max(1, math.Abs(v.Want)) {
+		res.Error = fmt.Sprintf("got %v, want %v", got, v.Want)
+		return res
+	}
+	res.Passed = true
+	return res
+}
+
+// Runner keeps the latest report so it can back a readiness check and the admin endpoint
+type Runner struct {
+	registry *domain.Registry
+	vectors  []Vector
+
+	mu     sync.Mutex
+	report Report
+}
+
+// NewRunner runs the vectors once and keeps the report
+func NewRunner(registry *domain.Registry, vectors []Vector) *Runner {
+	r := &Runner{registry: registry, vectors: vectors}
+	r.Rerun()
+	return r
+}
+
+// Rerun runs the vectors again and replaces the kept report
+func (r *Runner) Rerun() Report {
+	report := Run(r.registry, r.vectors)
+	r.mu.Lock()
+	r.report = report
+	r.mu.Unlock()
+	return report
+}
+
+// Report returns the latest report
+func (r *Runner) Report() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.report
+}
+
+// Check is a health check that fails while the latest report has failures
+func (r *Runner) Check(context.Context) error {
+	return r.Report().Err()
+}
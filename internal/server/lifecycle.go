@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ErrShutdownTimeout is returned by Run when in-flight requests were still running at the drain deadline
+var ErrShutdownTimeout = errors.New("connections did not drain before the shutdown deadline")
+
+// ListenError reports that the server could not bind its listen address
+type ListenError struct {
+	Addr string
+	Err  error
+}
+
+func (e *ListenError) Error() string {
+	return fmt.Sprintf("listening on %s: %v", e.Addr, e.Err)
+}
+
+func (e *ListenError) Unwrap() error {
+	return e.Err
+}
+
+// Run listens on srv.Addr and serves until ctx is done or serving fails.
+// Once ctx is done it stops accepting connections and waits up to drainTimeout for in-flight
+// requests to finish, forcibly closing any that remain and returning ErrShutdownTimeout.
+func Run(ctx context.Context, srv *http.Server, drainTimeout time.Duration) error {
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return &ListenError{Addr: srv.Addr, Err: err}
+	}
+	return Serve(ctx, srv, ln, drainTimeout)
+}
+
+// Serve is like Run but uses an existing listener
+func Serve(ctx context.Context, srv *http.Server, ln net.Listener, drainTimeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("serving: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return ErrShutdownTimeout
+		}
+		return fmt.Errorf("shutting down: %w", err)
+	}
+
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestServeDrains(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler func(started chan<- struct{}, release <-chan struct{}) http.HandlerFunc
+		wantErr error
+	}{
+		{
+			name: "fast handler finishes before shutdown",
+			handler: func(started chan<- struct{}, _ <-chan struct{}) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					started <- struct{}{}
+				}
+			},
+		},
+		{
+			name: "handler blocked past the drain timeout",
+			handler: func(started chan<- struct{}, release <-chan struct{}) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					started <- struct{}{}
+					<-release
+				}
+			},
+			wantErr: ErrShutdownTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			started := make(chan struct{}, 1)
+			release := make(chan struct{})
+			defer close(release)
+			srv := &http.Server{Handler: tc.handler(started, release)}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			served := make(chan error, 1)
+			go func() {
+				served <- Serve(ctx, srv, ln, 50*time.Millisecond)
+			}()
+
+			responded := make(chan struct{})
+			go func() {
+				defer close(responded)
+				if resp, err := http.Get("http://" + ln.Addr().String()); err == nil {
+					resp.Body.Close()
+				}
+			}()
+			<-started
+			if tc.wantErr == nil {
+				<-responded
+			}
+			cancel()
+
+			select {
+			case err := <-served:
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("Expected error %v, got %v", tc.wantErr, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Serve did not return after ctx was cancelled")
+			}
+		})
+	}
+}
+
+func TestRunListenError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	err = Run(context.Background(), &http.Server{Addr: ln.Addr().String()}, time.Second)
+	var listenErr *ListenError
+	if !errors.As(err, &listenErr) || listenErr.Addr != ln.Addr().String() {
+		t.Errorf("Expected a ListenError for %s, got %v", ln.Addr(), err)
+	}
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"tech-test/internal/config"
+	"tech-test/internal/domain"
+	"tech-test/internal/handlers"
+	"tech-test/internal/health"
+	"tech-test/internal/metrics"
+	"tech-test/internal/middleware"
+	"tech-test/internal/recording"
+	"tech-test/internal/selftest"
+)
+
+// App is the fully wired HTTP application: every route behind the request ID, access log and metrics middleware,
+// and optionally the request recorder
+type App struct {
+	handler  http.Handler
+	checker  *health.Checker
+	selfTest *selftest.Runner
+}
+
+// Option configures optional App dependencies
+type Option func(*options)
+
+type options struct {
+	logger   *slog.Logger
+	version  string
+	recorder *recording.Recorder
+}
+
+// WithLogger sets the logger used for access logs and domain tracing
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithVersion sets the build version reported by the health endpoints
+func WithVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// WithRecorder records every request and response, for later replay
+func WithRecorder(recorder *recording.Recorder) Option {
+	return func(o *options) {
+		o.recorder = recorder
+	}
+}
+
+// New builds the application described by cfg and runs the startup self-test.
+// Errors mean cfg is invalid; a failing self-test is not an error and is reported by SelfTestReport.
+func New(cfg config.Config, opts ...Option) (*App, error) {
+	o := options{
+		logger:  slog.New(slog.DiscardHandler),
+		version: "dev",
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	logger := o.logger
+
+	precision, err := handlers.ParsePrecision(cfg.Precision)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize domain services
+	mathService := domain.NewMathService(logger)
+	exactMathService := domain.NewExactMathService()
+	complexMathService := domain.NewComplexMathService(mathService)
+	statisticsService := domain.NewStatisticsService(mathService)
+	financeService := domain.NewFinanceService(mathService)
+
+	// Initialize handlers with dependency injection
+	h := handlers.NewHandlers(mathService,
+		handlers.WithDefaultPrecision(precision),
+		handlers.WithDecimalPlaces(cfg.DecimalPlaces),
+		handlers.WithLogger(logger),
+	)
+
+	// Statistics, cash-flow calculations and coordinate conversions are not registry operations, but the configured
+	// operations enable them by name all the same
+	var endpoints []endpoint
+	for _, name := range handlers.StatisticNames() {
+		endpoints = append(endpoints, endpoint{name, "/stats/" + name, h.Statistic(statisticsService, name)})
+	}
+	for _, name := range handlers.FinanceCalculations() {
+		endpoints = append(endpoints, endpoint{name, "/finance/" + name, h.Finance(financeService, name)})
+	}
+	endpoints = append(endpoints,
+		endpoint{"polar", "/polar", h.Polar(complexMathService)},
+		endpoint{"rect", "/rect", h.Rect(complexMathService)},
+	)
+
+	standard := domain.NewStandardRegistry(mathService, exactMathService, complexMathService, financeService)
+	registry, endpoints, err := selectOperations(standard, endpoints, cfg.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors, err := selftest.Load(cfg.SelfTestVectors)
+	if err != nil {
+		return nil, err
+	}
+	selfTest := selftest.NewRunner(registry, vectors)
+
+	// Setup routes
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ping", h.Ping)
+	// Expressions combine the four arithmetic operations, so they are only served when all four are enabled
+	if hasOperations(registry, "add", "sub", "mul", "div") {
+		mux.HandleFunc("/eval", h.Eval)
+	}
+	mux.HandleFunc("/batch", h.Batch(registry))
+	for _, op := range registry.Operations() {
+		mux.HandleFunc(operationPath(op), h.Operation(op))
+	}
+	names := registry.Names()
+	for _, e := range endpoints {
+		mux.HandleFunc(e.path, e.handler)
+		names = append(names, e.name)
+	}
+
+	checker := health.NewChecker(o.version, names)
+	checker.AddCheck("math_self_test", selfTest.Check)
+	mux.Handle("/healthz", checker.LivenessHandler())
+	mux.Handle("/readyz", checker.ReadinessHandler())
+	mux.Handle("/admin/selftest", selfTest.Handler())
+
+	metricsRegistry := metrics.NewRegistry()
+	metrics.RegisterRuntime(metricsRegistry)
+	httpMetrics := metrics.NewHTTPMetrics(metricsRegistry)
+	mux.Handle("/metrics", metricsRegistry.Handler())
+
+	handler := middleware.AccessLog(logger, middleware.Metrics(httpMetrics, mux))
+	if o.recorder != nil {
+		handler = middleware.Record(logger, o.recorder, handler)
+	}
+
+	return &App{
+		handler:  middleware.RequestID(handler),
+		checker:  checker,
+		selfTest: selfTest,
+	}, nil
+}
+
+// endpoint is a named route served outside the registry
+type endpoint struct {
+	name    string
+	path    string
+	handler http.HandlerFunc
+}
+
+// selectOperations splits names into registry operations and endpoints and returns the selected registry and
+// endpoints. An empty list selects everything; an unknown name is an error.
+func selectOperations(standard *domain.Registry, endpoints []endpoint, names []string) (*domain.Registry, []endpoint, error) {
+	if len(names) == 0 {
+		return standard, endpoints, nil
+	}
+
+	var operations []string
+	var selected []endpoint
+	for _, name := range names {
+		i := slices.IndexFunc(endpoints, func(e endpoint) bool { return e.name == name })
+		if i < 0 {
+			operations = append(operations, name)
+			continue
+		}
+		if !slices.ContainsFunc(selected, func(e endpoint) bool { return e.name == name }) {
+			selected = append(selected, endpoints[i])
+		}
+	}
+
+	// Select takes an empty list to mean every operation, but here it means the list named only endpoints
+	if len(operations) == 0 {
+		return domain.NewRegistry(), selected, nil
+	}
+	registry, err := standard.Select(operations)
+	if err != nil {
+		return nil, nil, err
+	}
+	return registry, selected, nil
+}
+
+// operationPath returns the path op is served at: /{name}, or /{group}/{name} for an operation in a group
+func operationPath(op domain.Operation) string {
+	if op.Group != "" {
+		return "/" + op.Group + "/" + op.Name
+	}
+	return "/" + op.Name
+}
+
+// hasOperations reports whether every named operation is registered
+func hasOperations(registry *domain.Registry, names ...string) bool {
+	for _, name := range names {
+		if _, ok := registry.Lookup(name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.handler.ServeHTTP(w, r)
+}
+
+// SelfTestReport returns the result of the most recent self-test run
+func (a *App) SelfTestReport() selftest.Report {
+	return a.selfTest.Report()
+}
+
+// SetDraining marks the application not ready ahead of shutdown
+func (a *App) SetDraining() {
+	a.checker.SetDraining()
+}
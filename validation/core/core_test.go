@@ -1,14 +1,14 @@
 package core_test
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 )
 
-const baseURL = "http://localhost:8080"
-
 func TestPingEndpoint(t *testing.T) {
 	resp, err := http.Get(baseURL + "/ping")
 	if err != nil {
@@ -198,3 +198,805 @@ func TestMethodNotAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalEndpointValid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"precedence", "expr=" + url.QueryEscape("2+3*4"), "14.00"},
+		{"parentheses", "expr=" + url.QueryEscape("(2+3)*4"), "20.00"},
+		{"unary minus", "expr=" + url.QueryEscape("-2*-(3+1)"), "8.00"},
+		{"variables", "expr=" + url.QueryEscape("((a+b)-c)*d/a") + "&a=10&b=5&c=3&d=2", "2.40"},
+		{"float precision", "expr=" + url.QueryEscape("1/3") + "&precision=float", "0.33"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + "/eval?" + tc.query)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if string(body) != tc.expected {
+				t.Errorf("Eval(%s): expected '%s', got '%s'", tc.query, tc.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestEvalEndpointInvalid(t *testing.T) {
+	testCases := []struct {
+		name       string
+		url        string
+		expectCode int
+	}{
+		{"missing expression", "/eval", http.StatusBadRequest},
+		{"unbalanced parentheses", "/eval?expr=" + url.QueryEscape("(1+2"), http.StatusBadRequest},
+		{"unknown variable", "/eval?expr=" + url.QueryEscape("a*2"), http.StatusBadRequest},
+		{"invalid variable", "/eval?expr=" + url.QueryEscape("a*2") + "&a=text", http.StatusBadRequest},
+		{"exact precision", "/eval?expr=" + url.QueryEscape("1/3") + "&precision=exact", http.StatusBadRequest},
+		{"fraction precision", "/eval?expr=" + url.QueryEscape("1/3") + "&precision=fraction", http.StatusBadRequest},
+		{"unknown precision", "/eval?expr=" + url.QueryEscape("1/3") + "&precision=double", http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectCode {
+				t.Errorf("Expected status %d, got %d", tc.expectCode, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if len(body) == 0 {
+				t.Error("Expected error message, got empty response")
+			}
+		})
+	}
+}
+
+func TestExactPrecision(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{"no float drift", "/add?a=0.1&b=0.2&precision=exact", "0.30"},
+		{"keeps extra decimals", "/sub?a=1.005&b=0.001&precision=exact", "1.004"},
+		{"large integers", "/add?a=9007199254740993&b=1&precision=exact", "9007199254740994.00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if string(body) != tc.expected {
+				t.Errorf("%s: expected '%s', got '%s'", tc.url, tc.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestJSONResponseFormat(t *testing.T) {
+	testCases := []struct {
+		name   string
+		url    string
+		accept string
+	}{
+		{"accept header", "/add?a=10&b=5", "application/json"},
+		{"format override", "/add?a=10&b=5&format=json", "text/plain"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, baseURL+tc.url, nil)
+			if err != nil {
+				t.Fatalf("Failed to build request: %v", err)
+			}
+			req.Header.Set("Accept", tc.accept)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Expected Content-Type 'application/json', got '%s'", contentType)
+			}
+
+			var body struct {
+				Operation string  `json:"operation"`
+				A         float64 `json:"a"`
+				B         float64 `json:"b"`
+				Result    float64 `json:"result"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response body: %v", err)
+			}
+
+			if body.Operation != "add" || body.A != 10 || body.B != 5 || body.Result != 15 {
+				t.Errorf("Unexpected JSON body: %+v", body)
+			}
+		})
+	}
+}
+
+func TestProblemJSONErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		method     string
+		url        string
+		expectCode int
+		code       string
+		parameter  string
+	}{
+		{"missing parameter", http.MethodGet, "/add?b=5", http.StatusBadRequest, "missing_parameter", "a"},
+		{"invalid number", http.MethodGet, "/sub?a=5&b=text", http.StatusBadRequest, "invalid_number", "b"},
+		{"method not allowed", http.MethodPost, "/add?a=1&b=2", http.StatusMethodNotAllowed, "method_not_allowed", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, baseURL+tc.url, nil)
+			if err != nil {
+				t.Fatalf("Failed to build request: %v", err)
+			}
+			req.Header.Set("Accept", "application/problem+json")
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectCode {
+				t.Errorf("Expected status %d, got %d", tc.expectCode, resp.StatusCode)
+			}
+
+			if contentType := resp.Header.Get("Content-Type"); contentType != "application/problem+json" {
+				t.Errorf("Expected Content-Type 'application/problem+json', got '%s'", contentType)
+			}
+
+			var problem struct {
+				Status    int    `json:"status"`
+				Code      string `json:"code"`
+				Parameter string `json:"parameter"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+				t.Fatalf("Failed to decode problem: %v", err)
+			}
+
+			if problem.Status != tc.expectCode || problem.Code != tc.code || problem.Parameter != tc.parameter {
+				t.Errorf("Unexpected problem: %+v", problem)
+			}
+		})
+	}
+}
+
+func TestDomainErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		url  string
+	}{
+		{"overflow", "/mul?a=1e308&b=10"},
+		{"not a number", "/add?a=Inf&b=-Inf"},
+		{"division by zero in expression", "/eval?expr=" + url.QueryEscape("1/(2-2)")},
+		{"modulo by zero", "/mod?a=1&b=0"},
+		{"integer division by zero", "/idiv?a=1&b=0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if len(body) == 0 {
+				t.Error("Expected error message, got empty response")
+			}
+		})
+	}
+}
+
+func TestBatchEndpoint(t *testing.T) {
+	body := `[{"op":"add","args":[10,5]},{"op":"mul","args":[1e308,10]},{"op":"nope","args":[1,2]},{"op":"sub","args":[7,3]}]`
+
+	resp, err := http.Post(baseURL+"/batch", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	var results []struct {
+		Op     string   `json:"op"`
+		Status int      `json:"status"`
+		Result *float64 `json:"result"`
+		Error  *struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(results))
+	}
+	if results[0].Status != http.StatusOK || results[0].Result == nil || *results[0].Result != 15 {
+		t.Errorf("Item 0: expected result 15, got %+v", results[0])
+	}
+	if results[1].Status != http.StatusUnprocessableEntity || results[1].Error == nil || results[1].Error.Code != "domain_error" {
+		t.Errorf("Item 1: expected domain_error, got %+v", results[1])
+	}
+	if results[2].Status != http.StatusBadRequest || results[2].Error == nil || results[2].Error.Code != "unknown_operation" {
+		t.Errorf("Item 2: expected unknown_operation, got %+v", results[2])
+	}
+	if results[3].Status != http.StatusOK || results[3].Result == nil || *results[3].Result != 4 {
+		t.Errorf("Item 3: expected result 4, got %+v", results[3])
+	}
+}
+
+func TestBatchOperandStrings(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		body     string
+		expected string
+	}{
+		{"fractions", "", `[{"op":"add","args":["1/3","1/6"]},{"op":"add","args":[1,2]},{"op":"sqrt","args":["1/4"]}]`,
+			`[{"op":"add","status":200,"result":"1/2"},{"op":"add","status":200,"result":3},{"op":"sqrt","status":200,"result":0.5}]`},
+		{"fraction precision", "?precision=fraction", `[{"op":"add","args":["1/3",1]},{"op":"mul","args":[0.5,4]}]`,
+			`[{"op":"add","status":200,"result":"4/3"},{"op":"mul","status":200,"result":"2"}]`},
+		{"complex numbers", "", `[{"op":"add","args":["3+4i",1]},{"op":"mul","args":["i","i"]},{"op":"sqrt","args":["-4i"]}]`,
+			`[{"op":"add","status":200,"result":{"re":4,"im":4}},{"op":"mul","status":200,"result":{"re":-1,"im":0}},{"op":"sqrt","status":200,"result":{"re":1.4142135623730951,"im":-1.4142135623730951}}]`},
+		{"complex precision", "?precision=complex", `[{"op":"sqrt","args":[-4]},{"op":"abs","args":["3-4i"]}]`,
+			`[{"op":"sqrt","status":200,"result":{"re":0,"im":2}},{"op":"abs","status":200,"result":5}]`},
+		{"decimal strings", "", `[{"op":"sub","args":["10","2.5"]}]`,
+			`[{"op":"sub","status":200,"result":7.5}]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Post(baseURL+"/batch"+tc.query, "application/json", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+			if string(body) != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, string(body))
+			}
+		})
+	}
+
+	t.Run("invalid string operand", func(t *testing.T) {
+		resp, err := http.Post(baseURL+"/batch", "application/json", strings.NewReader(`[{"op":"add","args":["one",1]},{"op":"add","args":[1,1]}]`))
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		var results []struct {
+			Status int `json:"status"`
+			Error  *struct {
+				Code      string `json:"code"`
+				Parameter string `json:"parameter"`
+			} `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+			t.Fatalf("Failed to decode response body: %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("Expected 2 results, got %d", len(results))
+		}
+		if results[0].Status != http.StatusBadRequest || results[0].Error == nil || results[0].Error.Code != "invalid_number" || results[0].Error.Parameter != "a" {
+			t.Errorf("Item 0: expected invalid_number for 'a', got %+v", results[0])
+		}
+		if results[1].Status != http.StatusOK {
+			t.Errorf("Item 1: expected status 200, got %d", results[1].Status)
+		}
+	})
+}
+
+func TestOperandLists(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{"repeated x", "/add?x=1&x=2&x=3", "6.00"},
+		{"values list", "/mul?values=1.5,2,4", "12.00"},
+		{"compensated sum", "/add?values=0.1,0.2,0.3&format=json", `{"operation":"add","values":[0.1,0.2,0.3],"result":0.6}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if string(body) != tc.expected {
+				t.Errorf("%s: expected '%s', got '%s'", tc.url, tc.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestModuloAndIntDivide(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{"modulo", "/mod?a=7&b=3", "1.00"},
+		{"truncated modulo of negative dividend", "/mod?a=-7&b=3", "-1.00"},
+		{"euclidean modulo of negative dividend", "/mod?a=-7&b=3&mode=euclidean", "2.00"},
+		{"euclidean modulo of negative divisor", "/mod?a=-7&b=-3&mode=euclidean", "2.00"},
+		{"decimal modulo", "/mod?a=5.5&b=2", "1.50"},
+		{"integer division", "/idiv?a=7&b=2", "3.00"},
+		{"truncated integer division", "/idiv?a=-7&b=2", "-3.00"},
+		{"euclidean integer division", "/idiv?a=-7&b=2&mode=euclidean", "-4.00"},
+		{"integer division agreeing with modulo", "/idiv?a=1&b=0.1", "9.00"},
+		{"modulo agreeing with integer division", "/mod?a=1&b=0.1", "0.10"},
+		{"exact euclidean modulo", "/mod?a=-7.5&b=2&mode=euclidean&precision=exact", "0.50"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if string(body) != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, string(body))
+			}
+		})
+	}
+
+	resp, err := http.Get(baseURL + "/mod?a=7&b=3&mode=floor")
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown mode, got %d", resp.StatusCode)
+	}
+}
+
+func TestScientificFunctions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{"power", "/pow?x=2&y=10", "1024.00"},
+		{"fractional power", "/pow?x=2&y=0.5", "1.41"},
+		{"square root", "/sqrt?x=16", "4.00"},
+		{"cube root of negative", "/root?x=-27&n=3", "-3.00"},
+		{"exponential", "/exp?x=1", "2.72"},
+		{"natural log", "/log?x=1", "0.00"},
+		{"log base 10", "/log10?x=1000", "3.00"},
+		{"log base 2", "/log2?x=8", "3.00"},
+		{"sine in radians", "/sin?x=0", "0.00"},
+		{"sine in degrees", "/sin?x=30&mode=deg", "0.50"},
+		{"cosine in degrees", "/cos?x=180&mode=deg", "-1.00"},
+		{"tangent in degrees", "/tan?x=45&mode=deg", "1.00"},
+		{"arcsine in degrees", "/asin?x=1&mode=deg", "90.00"},
+		{"arccosine in radians", "/acos?x=-1", "3.14"},
+		{"arctangent in degrees", "/atan?x=1&mode=deg", "45.00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if string(body) != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestScientificDomainErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		url    string
+		detail string
+	}{
+		{"square root of negative", "/sqrt?x=-1", "even root of a negative number"},
+		{"even root of negative", "/root?x=-16&n=4", "even root of a negative number"},
+		{"log of zero", "/log?x=0", "logarithm of zero or a negative number"},
+		{"log10 of negative", "/log10?x=-5", "logarithm of zero or a negative number"},
+		{"arcsine out of range", "/asin?x=2", "argument is outside the domain of the function"},
+		{"tangent of 90 degrees", "/tan?x=90&mode=deg", "argument is outside the domain of the function"},
+		{"fractional power of negative", "/pow?x=-8&y=0.5", "argument is outside the domain of the function"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if string(body) != tc.detail {
+				t.Errorf("Expected '%s', got '%s'", tc.detail, string(body))
+			}
+		})
+	}
+}
+
+func TestStatistics(t *testing.T) {
+	testCases := []struct {
+		name        string
+		url         string
+		contentType string
+		body        string
+		status      int
+		expected    string
+	}{
+		{"mean of values", "/stats/mean?values=1,2,3,4", "", "", http.StatusOK, "2.50"},
+		{"mean whose sum overflows", "/stats/mean?values=1e308,1e308&format=json", "", "", http.StatusOK, `{"statistic":"mean","count":2,"result":1e+308}`},
+		{"mean of opposite extremes", "/stats/mean?values=1.5e308,-1.5e308,3e307&format=json", "", "", http.StatusOK, `{"statistic":"mean","count":3,"result":1e+307}`},
+		{"median of repeated x", "/stats/median?x=3&x=1&x=2&x=10", "", "", http.StatusOK, "2.50"},
+		{"every mode", "/stats/mode?values=1,2,2,3,3", "", "", http.StatusOK, "2.00,3.00"},
+		{"sample variance", "/stats/variance?values=2,4,4,4,5,5,7,9", "", "", http.StatusOK, "4.57"},
+		{"population stddev", "/stats/stddev?values=2,4,4,4,5,5,7,9&kind=population", "", "", http.StatusOK, "2.00"},
+		{"interpolated percentile", "/stats/percentile?values=1,2,3,4&p=25", "", "", http.StatusOK, "1.75"},
+		{"min", "/stats/min?values=3,-1,2", "", "", http.StatusOK, "-1.00"},
+		{"histogram", "/stats/histogram?values=1,2,3,4&bins=2", "", "", http.StatusOK, "[1.00, 2.50): 2\n[2.50, 4.00]: 2\n"},
+		{"histogram of equal values", "/stats/histogram?values=5,5,5&bins=3", "", "", http.StatusOK,
+			"[4.50, 4.83): 0\n[4.83, 5.17): 3\n[5.17, 5.50]: 0\n"},
+		{"histogram of one value", "/stats/histogram?values=5&bins=2", "", "", http.StatusOK, "[4.50, 5.00): 0\n[5.00, 5.50]: 1\n"},
+		{"JSON body", "/stats/max", "application/json", "[4, 9.5, -2]", http.StatusOK, "9.50"},
+		{"CSV column by name", "/stats/mean?column=amount", "text/csv", "name,amount\na,10\nb,20\n", http.StatusOK, "15.00"},
+		{"CSV column by index", "/stats/mean?column=0", "text/csv", "1,100\n3,100\n", http.StatusOK, "2.00"},
+		{"CSV every field", "/stats/max", "text/csv", "1,2\n3\n", http.StatusOK, "3.00"},
+		{"no dataset", "/stats/mean", "", "", http.StatusBadRequest, ""},
+		{"empty JSON dataset", "/stats/mean", "application/json", "[]", http.StatusUnprocessableEntity, "dataset is empty"},
+		{"one value sample variance", "/stats/variance?values=5", "", "", http.StatusUnprocessableEntity, "sample statistics need at least two values"},
+		{"percentile out of range", "/stats/percentile?values=1,2&p=101", "", "", http.StatusBadRequest, ""},
+		{"unknown statistic", "/stats/nope?values=1", "", "", http.StatusNotFound, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp *http.Response
+			var err error
+			if tc.contentType == "" {
+				resp, err = http.Get(baseURL + tc.url)
+			} else {
+				resp, err = http.Post(baseURL+tc.url, tc.contentType, strings.NewReader(tc.body))
+			}
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Errorf("Expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if tc.expected != "" && string(body) != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestFinance(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		status   int
+		expected string
+	}{
+		{"mortgage payment", "/finance/pmt?rate=0.005&n=360&pv=200000&fv=0", http.StatusOK, "-1199.10"},
+		{"annuity due payment", "/finance/pmt?rate=0.005&n=360&pv=200000&fv=0&mode=begin", http.StatusOK, "-1193.14"},
+		{"present value", "/finance/pv?rate=0.05&n=10&pmt=-100&fv=0", http.StatusOK, "772.17"},
+		{"future value at zero rate", "/finance/fv?rate=0&n=10&pmt=-100&pv=-50", http.StatusOK, "1050.00"},
+		{"rate of -100%", "/finance/pv?rate=-1&n=1&pmt=1&fv=0", http.StatusUnprocessableEntity, "argument is outside the domain of the function"},
+		{"net present value", "/finance/npv?rate=0.1&values=-1000,300,400,500", http.StatusOK, "-21.04"},
+		{"internal rate of return", "/finance/irr?values=-100,0,121", http.StatusOK, "0.10"},
+		{"long series", "/finance/irr?values=-1000," + strings.Repeat("0,", 400) + "5000", http.StatusOK, "0.00"},
+		{"zero rate of return", "/finance/irr?values=-1," + strings.Repeat("0,", 40) + "1", http.StatusOK, "0.00"},
+		{"no outflow", "/finance/irr?values=100,200", http.StatusUnprocessableEntity, "cash flows need at least one outflow and one inflow"},
+		{"amortization schedule", "/finance/amortization?principal=100&rate=0.1&n=2&format=csv", http.StatusOK,
+			"period,payment,interest,principal,balance\n1,57.62,10.00,47.62,52.38\n2,57.62,5.24,52.38,0.00\n"},
+		{"fractional periods", "/finance/amortization?principal=100&rate=0.1&n=2.5", http.StatusBadRequest, ""},
+		{"unknown calculation", "/finance/nope", http.StatusNotFound, ""},
+		{"annuity formulas are not served at the top level", "/pmt?rate=0.005&n=360&pv=200000&fv=0", http.StatusNotFound, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Errorf("Expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if tc.expected != "" && string(body) != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestAmortizationNegotiation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{"CSV preferred", "text/csv, application/json;q=0.5", "text/csv"},
+		{"CSV weighted below JSON", "application/json, text/csv;q=0.1", "application/json"},
+		{"CSV not acceptable", "text/csv;q=0.0", "text/plain"},
+		{"CSV only", "text/csv", "text/csv"},
+		{"no preference", "", "text/plain"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, baseURL+"/finance/amortization?principal=100&rate=0.1&n=2", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+			if got := resp.Header.Get("Content-Type"); got != tc.contentType {
+				t.Errorf("Expected Content-Type '%s', got '%s'", tc.contentType, got)
+			}
+		})
+	}
+}
+
+func TestFractions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		status   int
+		expected string
+	}{
+		{"lowest terms", "/add?a=1/3&b=1/6", http.StatusOK, "1/2"},
+		{"whole result", "/mul?a=2/3&b=3", http.StatusOK, "2"},
+		{"mixed with decimal", "/add?a=0.5&b=1/4", http.StatusOK, "3/4"},
+		{"operand list", "/mul?values=1/3,1/3,1/2", http.StatusOK, "1/18"},
+		{"decimal rendering", "/add?a=1/3&b=1/3&render=decimal", http.StatusOK, "0.67"},
+		{"explicit precision", "/sub?a=1&b=0.25&precision=fraction", http.StatusOK, "3/4"},
+		{"float precision", "/add?a=1/4&b=1/4&precision=float", http.StatusOK, "0.50"},
+		{"float only operation", "/sqrt?x=1/4", http.StatusOK, "0.50"},
+		{"division by zero", "/div?a=1/3&b=0", http.StatusUnprocessableEntity, "division by zero"},
+		{"zero denominator", "/add?a=1/0&b=1", http.StatusBadRequest, "parameter 'a' must be a valid number"},
+		{"leading zero is decimal", "/add?a=010/3&b=0", http.StatusOK, "10/3"},
+		{"leading zero in exact precision", "/add?a=010&b=0.5&precision=exact", http.StatusOK, "10.50"},
+		{"base prefix", "/add?a=0x10/1&b=0", http.StatusBadRequest, "parameter 'a' must be a valid number"},
+		{"unknown rendering", "/add?a=1/3&b=1&render=roman", http.StatusBadRequest, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Errorf("Expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if tc.expected != "" && string(body) != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestComplexNumbers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		status   int
+		expected string
+	}{
+		{"addition", "/add?a=3%2B4i&b=1-2i", http.StatusOK, "4.00+2.00i"},
+		{"unescaped plus", "/add?a=3+4i&b=1", http.StatusOK, "4.00+4.00i"},
+		{"i squared", "/mul?a=i&b=i", http.StatusOK, "-1.00+0.00i"},
+		{"division", "/div?a=1&b=i", http.StatusOK, "0.00-1.00i"},
+		{"operand list", "/add?values=1%2Bi,2,-3i", http.StatusOK, "3.00-2.00i"},
+		{"square root of negative", "/sqrt?x=-4&precision=complex", http.StatusOK, "0.00+2.00i"},
+		{"euler's identity", "/exp?x=3.141592653589793i", http.StatusOK, "-1.00+0.00i"},
+		{"modulus", "/abs?x=3%2B4i", http.StatusOK, "5.00"},
+		{"modulus of a real", "/abs?x=-3", http.StatusOK, "3.00"},
+		{"modulus in complex precision", "/abs?x=-3&precision=complex", http.StatusOK, "3.00"},
+		{"modulus as JSON", "/abs?x=3%2B4i&format=json", http.StatusOK, `{"operation":"abs","x":{"re":3,"im":4},"result":5}`},
+		{"argument", "/arg?x=i", http.StatusOK, "1.57"},
+		{"conjugate", "/conj?x=3%2B4i", http.StatusOK, "3.00-4.00i"},
+		{"polar form", "/polar?z=-2&mode=deg", http.StatusOK, "2.00,180.00"},
+		{"rectangular form", "/rect?r=2&theta=90&mode=deg", http.StatusOK, "0.00+2.00i"},
+		{"division by zero", "/div?a=1%2Bi&b=0", http.StatusUnprocessableEntity, "division by zero"},
+		{"arctangent pole", "/atan?x=i", http.StatusUnprocessableEntity, "argument is outside the domain of the function"},
+		{"real only operation", "/mod?a=3%2B4i&b=2", http.StatusBadRequest, "operation 'mod' does not support complex precision"},
+		{"malformed", "/add?a=3%2B4j&b=1", http.StatusBadRequest, "parameter 'a' must be a valid number"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Errorf("Expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if string(body) != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestComplexJSON(t *testing.T) {
+	resp, err := http.Get(baseURL + "/mul?a=1%2B2i&b=3-i&format=json")
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		A      map[string]float64 `json:"a"`
+		Result map[string]float64 `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+
+	if body.A["re"] != 1 || body.A["im"] != 2 {
+		t.Errorf("Expected a to be {re: 1, im: 2}, got %v", body.A)
+	}
+	if body.Result["re"] != 5 || body.Result["im"] != 5 {
+		t.Errorf("Expected result {re: 5, im: 5}, got %v", body.Result)
+	}
+}
@@ -0,0 +1,71 @@
+package core_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestMultiplyEndpointValid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{"distinct operands", "5", "3", "15.00"},
+		{"operands in the other order", "3", "5", "15.00"},
+		{"decimal operands", "2.5", "4", "10.00"},
+		{"zero second operand", "7", "0", "0.00"},
+		{"negative second operand", "3", "-4", "-12.00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + "/mul?a=" + tc.a + "&b=" + tc.b)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+
+			if string(body) != tc.expected {
+				t.Errorf("Multiply(%s, %s): expected '%s', got '%s'", tc.a, tc.b, tc.expected, string(body))
+			}
+		})
+	}
+}
+
+func TestMultiplyEndpointInvalid(t *testing.T) {
+	testCases := []struct {
+		name       string
+		url        string
+		expectCode int
+	}{
+		{"missing parameter a", "/mul?b=5", http.StatusBadRequest},
+		{"missing parameter b", "/mul?a=5", http.StatusBadRequest},
+		{"invalid parameter b", "/mul?a=5&b=text", http.StatusBadRequest},
+		{"no parameters", "/mul", http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(baseURL + tc.url)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.expectCode {
+				t.Errorf("Expected status %d, got %d", tc.expectCode, resp.StatusCode)
+			}
+		})
+	}
+}
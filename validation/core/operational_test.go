@@ -0,0 +1,329 @@
+package core_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"tech-test/internal/config"
+	"tech-test/internal/server"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Run("echoes the caller's ID", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, baseURL+"/ping", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Request-ID", "trace-123")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		resp.Body.Close()
+
+		if got := resp.Header.Get("X-Request-ID"); got != "trace-123" {
+			t.Errorf("Expected X-Request-ID 'trace-123', got '%s'", got)
+		}
+	})
+
+	t.Run("generates an ID when none is sent", func(t *testing.T) {
+		first, err := http.Get(baseURL + "/ping")
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		first.Body.Close()
+		second, err := http.Get(baseURL + "/add?a=1&b=x")
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		second.Body.Close()
+
+		id := first.Header.Get("X-Request-ID")
+		if !regexp.MustCompile(`^[0-9a-f]{16}$`).MatchString(id) {
+			t.Errorf("Expected a 16 hex digit X-Request-ID, got '%s'", id)
+		}
+		if other := second.Header.Get("X-Request-ID"); other == "" || other == id {
+			t.Errorf("Expected a fresh X-Request-ID on an error response, got '%s' after '%s'", other, id)
+		}
+	})
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	for _, url := range []string{"/add?a=1&b=2", "/add?a=1&b=x"} {
+		resp, err := http.Get(baseURL + url)
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	resp, err := http.Get(baseURL + "/metrics")
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("Expected the Prometheus text format, got Content-Type '%s'", got)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+	for _, want := range []string{
+		"# TYPE techtest_http_requests_total counter\n",
+		`techtest_http_requests_total{operation="add",code="200"} `,
+		`techtest_http_request_errors_total{operation="add",code="400"} `,
+		"# TYPE techtest_http_request_duration_seconds histogram\n",
+		`techtest_http_request_duration_seconds_bucket{operation="add",le="+Inf"} `,
+		"# TYPE go_goroutines gauge\n",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("Expected metrics to contain %q", want)
+		}
+	}
+}
+
+// healthReport is the body of /healthz and /readyz
+type healthReport struct {
+	Status     string   `json:"status"`
+	Version    string   `json:"version"`
+	Operations []string `json:"operations"`
+	Draining   bool     `json:"draining"`
+	Checks     []struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	} `json:"checks"`
+}
+
+// getJSON requests url and decodes its JSON body into v, returning the status code
+func getJSON(t *testing.T, method, url string, v any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+		t.Errorf("Expected a JSON response, got Content-Type '%s'", got)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+	return resp.StatusCode
+}
+
+// startApp serves a separate application built from cfg, for tests that change its state
+func startApp(t *testing.T, cfg config.Config) (*server.App, string) {
+	t.Helper()
+	app, err := server.New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to build server: %v", err)
+	}
+	srv := httptest.NewServer(app)
+	t.Cleanup(srv.Close)
+	return app, srv.URL
+}
+
+func TestHealthEndpoints(t *testing.T) {
+	testCases := []struct {
+		name       string
+		url        string
+		wantStatus string
+	}{
+		{name: "liveness", url: "/healthz", wantStatus: "ok"},
+		{name: "readiness", url: "/readyz", wantStatus: "ready"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var report healthReport
+			if code := getJSON(t, http.MethodGet, baseURL+tc.url, &report); code != http.StatusOK {
+				t.Errorf("Expected status 200, got %d", code)
+			}
+			if report.Status != tc.wantStatus {
+				t.Errorf("Expected status '%s', got '%s'", tc.wantStatus, report.Status)
+			}
+			if report.Version == "" {
+				t.Error("Expected a version")
+			}
+			if !slices.Contains(report.Operations, "add") {
+				t.Errorf("Expected operations to include 'add', got %v", report.Operations)
+			}
+			if report.Draining {
+				t.Error("Expected the server not to be draining")
+			}
+			if len(report.Checks) == 0 {
+				t.Fatal("Expected at least one check")
+			}
+			for _, check := range report.Checks {
+				if check.Status != "pass" {
+					t.Errorf("Expected check '%s' to pass, got '%s'", check.Name, check.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestReadinessWhileDraining(t *testing.T) {
+	app, url := startApp(t, config.Default())
+	app.SetDraining()
+
+	var ready healthReport
+	if code := getJSON(t, http.MethodGet, url+"/readyz", &ready); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", code)
+	}
+	if ready.Status != "not_ready" || !ready.Draining {
+		t.Errorf("Expected a draining 'not_ready' report, got status '%s' draining %v", ready.Status, ready.Draining)
+	}
+
+	var live healthReport
+	if code := getJSON(t, http.MethodGet, url+"/healthz", &live); code != http.StatusOK {
+		t.Errorf("Expected a draining server to stay live with status 200, got %d", code)
+	}
+}
+
+// selfTestReport is the body of /admin/selftest
+type selfTestReport struct {
+	OK     bool      `json:"ok"`
+	RanAt  time.Time `json:"ran_at"`
+	Passed int       `json:"passed"`
+	Failed int       `json:"failed"`
+}
+
+func TestSelfTestEndpoint(t *testing.T) {
+	var latest selfTestReport
+	if code := getJSON(t, http.MethodGet, baseURL+"/admin/selftest", &latest); code != http.StatusOK {
+		t.Errorf("Expected status 200 for GET, got %d", code)
+	}
+	if !latest.OK || latest.Passed == 0 || latest.Failed != 0 {
+		t.Errorf("Expected the startup run to pass, got ok %v with %d passed and %d failed", latest.OK, latest.Passed, latest.Failed)
+	}
+
+	var rerun selfTestReport
+	if code := getJSON(t, http.MethodPost, baseURL+"/admin/selftest", &rerun); code != http.StatusOK {
+		t.Errorf("Expected status 200 for POST, got %d", code)
+	}
+	if !rerun.OK || rerun.Passed != latest.Passed {
+		t.Errorf("Expected the re-run to pass all %d vectors, got ok %v with %d passed", latest.Passed, rerun.OK, rerun.Passed)
+	}
+	if rerun.RanAt.Before(latest.RanAt) {
+		t.Errorf("Expected the re-run at %v to be no earlier than the startup run at %v", rerun.RanAt, latest.RanAt)
+	}
+}
+
+func TestReadinessWithFailingSelfTest(t *testing.T) {
+	vectors := filepath.Join(t.TempDir(), "vectors.json")
+	if err := os.WriteFile(vectors, []byte(`[{"operation": "add", "args": [1, 1], "want": 3}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Default()
+	cfg.SelfTestVectors = vectors
+	cfg.SelfTestMode = "unready"
+	app, url := startApp(t, cfg)
+
+	if app.SelfTestReport().OK {
+		t.Error("Expected the startup self-test to fail")
+	}
+
+	var ready healthReport
+	if code := getJSON(t, http.MethodGet, url+"/readyz", &ready); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", code)
+	}
+	if ready.Status != "not_ready" || ready.Draining {
+		t.Errorf("Expected a 'not_ready' report that is not draining, got status '%s' draining %v", ready.Status, ready.Draining)
+	}
+
+	var rerun selfTestReport
+	if code := getJSON(t, http.MethodPost, url+"/admin/selftest", &rerun); code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", code)
+	}
+	if rerun.OK || rerun.Failed != 1 {
+		t.Errorf("Expected the re-run to fail one vector, got ok %v with %d failed", rerun.OK, rerun.Failed)
+	}
+}
+
+func TestOperationsSelectEndpoints(t *testing.T) {
+	testCases := []struct {
+		name       string
+		operations []string
+		wantOK     []string
+		wantAbsent []string
+	}{
+		{
+			name:       "registry operations and endpoints",
+			operations: []string{"add", "mean", "npv", "polar"},
+			wantOK:     []string{"/add?a=1&b=2", "/stats/mean?values=1,2", "/finance/npv?rate=0.1&values=-100,110", "/polar?z=3%2B4i"},
+			wantAbsent: []string{"/sub?a=1&b=2", "/stats/median?values=1,2", "/finance/irr?values=-100,110", "/rect?r=1&theta=0"},
+		},
+		{
+			name:       "endpoints only",
+			operations: []string{"median"},
+			wantOK:     []string{"/stats/median?values=1,2"},
+			wantAbsent: []string{"/add?a=1&b=2", "/finance/pmt?rate=0&n=10&pv=-100&fv=0", "/stats/mean?values=1,2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Operations = tc.operations
+			_, url := startApp(t, cfg)
+
+			for _, path := range tc.wantOK {
+				if code := getStatus(t, url+path); code != http.StatusOK {
+					t.Errorf("Expected status 200 for %s, got %d", path, code)
+				}
+			}
+			for _, path := range tc.wantAbsent {
+				if code := getStatus(t, url+path); code != http.StatusNotFound {
+					t.Errorf("Expected status 404 for %s, got %d", path, code)
+				}
+			}
+
+			var report healthReport
+			getJSON(t, http.MethodGet, url+"/healthz", &report)
+			if !slices.Equal(report.Operations, tc.operations) {
+				t.Errorf("Expected /healthz to list %v, got %v", tc.operations, report.Operations)
+			}
+		})
+	}
+}
+
+func TestOperationsRejectUnknownNames(t *testing.T) {
+	cfg := config.Default()
+	cfg.Operations = []string{"add", "nope"}
+	if _, err := server.New(cfg); err == nil {
+		t.Error("Expected an error for an unknown operation")
+	}
+}
+
+// getStatus requests url and returns the status code
+func getStatus(t *testing.T, url string) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
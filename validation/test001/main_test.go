@@ -0,0 +1,24 @@
+package test001_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"tech-test/internal/harness"
+)
+
+var baseURL string
+
+func TestMain(m *testing.M) {
+	url, stop, err := harness.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start server: %v\n", err)
+		os.Exit(1)
+	}
+	baseURL = url
+
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
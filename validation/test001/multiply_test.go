@@ -6,8 +6,6 @@ import (
 	"testing"
 )
 
-const baseURL = "http://localhost:8080"
-
 func TestMultiplyEndpointValid(t *testing.T) {
 	testCases := []struct {
 		name     string
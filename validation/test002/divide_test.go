@@ -6,8 +6,6 @@ import (
 	"testing"
 )
 
-const baseURL = "http://localhost:8080"
-
 func TestDivideEndpointExists(t *testing.T) {
 	resp, err := http.Get(baseURL + "/div?a=10&b=2")
 	if err != nil {
@@ -78,10 +76,10 @@ func TestDivideByZero(t *testing.T) {
 		t.Skip("Divide endpoint not implemented yet")
 	}
 
-	// Should return an error status (400) for division by zero
-	if resp.StatusCode != http.StatusBadRequest {
+	// Should return an error status (422) for division by zero
+	if resp.StatusCode != http.StatusUnprocessableEntity {
 		body, _ := io.ReadAll(resp.Body)
-		t.Errorf("Division by zero should return BadRequest status, got %d. Response: %s", resp.StatusCode, string(body))
+		t.Errorf("Division by zero should return UnprocessableEntity status, got %d. Response: %s", resp.StatusCode, string(body))
 		return
 	}
 
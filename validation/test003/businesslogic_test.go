@@ -6,8 +6,6 @@ import (
 	"testing"
 )
 
-const baseURL = "http://localhost:8080"
-
 func TestBusinessLogicEndpointExists(t *testing.T) {
 	resp, err := http.Get(baseURL + "/businesslogic?a=10&b=5&c=3&d=2")
 	if err != nil {